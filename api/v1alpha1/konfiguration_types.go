@@ -17,6 +17,9 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"slices"
+	"time"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -85,6 +88,35 @@ type Iteration struct {
 	// Defines variable inputs specific for the given iteration.
 	// These variables are merged on top of the default variables, and thus may choose to override default ones.
 	Variables []NameValuePair `json:"variables,omitempty"`
+
+	// Defines overrides of .spec.destination specific for the given iteration.
+	// +optional
+	Destination *IterationDestination `json:"destination,omitempty"`
+
+	// Labels of the iteration, used to select it into the waves of .spec.reconciliation.rollout.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// IterationDestination defines per iteration overrides of where and how to store the rendered konfiguration.
+type IterationDestination struct {
+	// Overrides the namespace where the rendered Kubernetes manifests of the iteration will be applied.
+	// Defaults to .spec.destination.namespace.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// Overrides the rules on how to name the rendered Kubernetes manifests of the iteration.
+	// Replaces .spec.destination.naming as a whole when set.
+	// +optional
+	Naming *NamingOptions `json:"naming,omitempty"`
+
+	// Explicit .metadata.name of the rendered Kubernetes manifests of the iteration.
+	// Takes precedence over any naming rules.
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:Pattern="^[a-z0-9]([-a-z0-9.]*[a-z0-9])?$"
+	// +optional
+	Name string `json:"name,omitempty"`
 }
 
 // NameValuePair is a simple structure for defining input fields by name and value.
@@ -107,25 +139,209 @@ type Destination struct {
 	// Defines rules on how to name the rendered Kubernetes manifests.
 	// +required
 	Naming NamingOptions `json:"naming"`
+
+	// Defines what kind of Kubernetes manifests to render and how to store the data in them.
+	// +optional
+	Output Output `json:"output,omitempty"`
+
+	// Defines workloads to notify when the rendered data of an iteration changes, so they pick up the new
+	// data without waiting for their own reconciliation interval.
+	// +optional
+	RolloutTriggers []RolloutTrigger `json:"rolloutTriggers,omitempty"`
 }
 
-func (n *NamingOptions) Render(core string) string {
-	name := core
+// RolloutTriggerKind defines the kinds of workloads that can be notified about changed data.
+// +kubebuilder:validation:Enum=Deployment;HelmRelease;App
+type RolloutTriggerKind string
+
+const (
+	RolloutTriggerKindDeployment  RolloutTriggerKind = "Deployment"
+	RolloutTriggerKindHelmRelease RolloutTriggerKind = "HelmRelease"
+	RolloutTriggerKindApp         RolloutTriggerKind = "App"
+)
 
-	separator := ""
-	if n.UseSeparator {
-		separator = "-"
+// RolloutTrigger defines a workload to notify when the rendered data of an iteration changes.
+// Deployments get their pod template annotated to roll out new pods, Flux HelmReleases get a reconciliation
+// requested and Giant Swarm App CRs get annotated to be reconciled by app-operator.
+type RolloutTrigger struct {
+	// Kind of the workload to notify.
+	// +required
+	Kind RolloutTriggerKind `json:"kind"`
+
+	// Name of the workload to notify. May reference the resolved variables of the iteration,
+	// e.g. `${konfiguration.iteration}`.
+	// +kubebuilder:validation:MinLength=1
+	// +required
+	Name string `json:"name"`
+
+	// Namespace of the workload to notify. May reference the resolved variables of the iteration.
+	// Defaults to the destination namespace of the iteration.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// Restricts the trigger to the listed iterations. Applies to all iterations when empty.
+	// +optional
+	Iterations []string `json:"iterations,omitempty"`
+}
+
+// AppliesTo tells whether the trigger should be fired for the given iteration.
+func (t *RolloutTrigger) AppliesTo(iterationName string) bool {
+	return len(t.Iterations) == 0 || slices.Contains(t.Iterations, iterationName)
+}
+
+// OutputKind defines which kinds of Kubernetes manifests to render.
+// +kubebuilder:validation:Enum=Both;ConfigMap;Secret
+type OutputKind string
+
+const (
+	// DefaultImmutableRetain is the default number of previous immutable generations to keep.
+	DefaultImmutableRetain = 2
+)
+
+const (
+	OutputKindBoth      OutputKind = "Both"
+	OutputKindConfigMap OutputKind = "ConfigMap"
+	OutputKindSecret    OutputKind = "Secret"
+)
+
+// Output defines what kind of Kubernetes manifests to render and how to store the data in them.
+type Output struct {
+	// Kind selects whether to render a ConfigMap, a Secret or both for each iteration.
+	// Manifests of the disabled kind are neither checked nor applied. Defaults to Both.
+	// +kubebuilder:default:=Both
+	// +optional
+	Kind OutputKind `json:"kind,omitempty"`
+
+	// The key to store the rendered data under in the ConfigMap. Defaults to configmap-values.yaml.
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:Pattern="^[-._a-zA-Z0-9]+$"
+	// +kubebuilder:validation:MaxLength=253
+	// +optional
+	ConfigMapDataKey string `json:"configMapDataKey,omitempty"`
+
+	// The key to store the rendered data under in the Secret. Defaults to secret-values.yaml.
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:Pattern="^[-._a-zA-Z0-9]+$"
+	// +kubebuilder:validation:MaxLength=253
+	// +optional
+	SecretDataKey string `json:"secretDataKey,omitempty"`
+
+	// Immutable renders immutable ConfigMaps and Secrets named with a hash of their content appended to the rendered
+	// name, instead of updating the same ConfigMap and Secret in place. The current names are published
+	// in the report referenced by .status.report.
+	// +optional
+	Immutable *ImmutableOutput `json:"immutable,omitempty"`
+}
+
+// ImmutableOutput defines how to manage immutable, content-hashed ConfigMaps and Secrets.
+type ImmutableOutput struct {
+	// The number of previous generations to keep besides the current one. Older generations are deleted.
+	// Defaults to 2.
+	// +kubebuilder:default:=2
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	Retain *int32 `json:"retain,omitempty"`
+}
+
+// GetRetain returns the number of previous generations to keep.
+func (i *ImmutableOutput) GetRetain() int {
+	if i.Retain == nil {
+		return DefaultImmutableRetain
+	}
+
+	return int(*i.Retain)
+}
+
+// RendersConfigMap tells whether ConfigMaps should be rendered and applied.
+func (o *Output) RendersConfigMap() bool {
+	return o.Kind != OutputKindSecret
+}
+
+// RendersSecret tells whether Secrets should be rendered and applied.
+func (o *Output) RendersSecret() bool {
+	return o.Kind != OutputKindConfigMap
+}
+
+// RenderNamespace returns the namespace to apply the rendered manifests of the given iteration to,
+// falling back to the global namespace when the iteration does not override it.
+func (d *Destination) RenderNamespace(iteration Iteration) string {
+	if iteration.Destination != nil && iteration.Destination.Namespace != "" {
+		return iteration.Destination.Namespace
+	}
+
+	return d.Namespace
+}
+
+// RenderName returns the name of the rendered manifests of the given iteration. An explicit name on the iteration
+// takes precedence, then the naming rules of the iteration, falling back to the global naming rules.
+// The variables are only used by templated naming rules.
+func (d *Destination) RenderName(iterationName string, iteration Iteration, variables map[string]string) (string, error) {
+	if iteration.Destination != nil && iteration.Destination.Name != "" {
+		return iteration.Destination.Name, nil
+	}
+
+	return d.namingFor(iteration).Render(iterationName, variables)
+}
+
+// IsTemplatedName tells whether the name of the rendered manifests of the given iteration depends on its variables.
+func (d *Destination) IsTemplatedName(iteration Iteration) bool {
+	if iteration.Destination != nil && iteration.Destination.Name != "" {
+		return false
+	}
+
+	return d.namingFor(iteration).Template != ""
+}
+
+// NameMaxLength returns the maximum length of the names of the rendered manifests of the given iteration.
+func (d *Destination) NameMaxLength(iteration Iteration) int {
+	return d.namingFor(iteration).GetMaxLength()
+}
+
+func (d *Destination) namingFor(iteration Iteration) *NamingOptions {
+	if iteration.Destination != nil && iteration.Destination.Naming != nil {
+		return iteration.Destination.Naming
 	}
 
-	if n.Prefix != "" {
-		name = n.Prefix + separator + name
+	return &d.Naming
+}
+
+// Render returns the name for the given iteration name. A template takes precedence over the prefix and suffix.
+// Names longer than the maximum length are truncated and suffixed with a hash of the full name to keep them unique.
+func (n *NamingOptions) Render(core string, variables map[string]string) (string, error) {
+	name := core
+
+	if n.Template != "" {
+		rendered, err := renderNameTemplate(n.Template, core, variables)
+		if err != nil {
+			return "", err
+		}
+
+		name = rendered
+	} else {
+		separator := ""
+		if n.UseSeparator {
+			separator = "-"
+		}
+
+		if n.Prefix != "" {
+			name = n.Prefix + separator + name
+		}
+
+		if n.Suffix != "" {
+			name = name + separator + n.Suffix
+		}
 	}
 
-	if n.Suffix != "" {
-		name = name + separator + n.Suffix
+	return validateName(truncateName(name, n.GetMaxLength()))
+}
+
+// GetMaxLength returns the maximum length of rendered names, defaulting to DefaultNameMaxLength.
+func (n *NamingOptions) GetMaxLength() int {
+	if n.MaxLength == 0 {
+		return DefaultNameMaxLength
 	}
 
-	return name
+	return n.MaxLength
 }
 
 // NamingOptions defines rules on how to name the rendered Kubernetes manifests.
@@ -146,8 +362,30 @@ type NamingOptions struct {
 	// +kubebuilder:default:=true
 	// +optional
 	UseSeparator bool `json:"useSeparator,omitempty"`
+
+	// Template is a Go template to render the name with, taking precedence over prefix and suffix.
+	// The resolved variables of the iteration are accessible by name, e.g. `{{ .cluster }}`, or via
+	// the index function when they contain dots, e.g. `{{ index . "konfiguration.namespace" }}`.
+	// The iteration name is accessible as `{{ .iteration }}`, shadowing any variable with the same name.
+	// +optional
+	Template string `json:"template,omitempty"`
+
+	// MaxLength is the maximum length of the rendered name. Longer names are truncated and suffixed with
+	// a hash of the full name. Defaults to 253, set it to 63 when the name must be a valid label value.
+	// +kubebuilder:validation:Minimum=16
+	// +kubebuilder:validation:Maximum=253
+	// +optional
+	MaxLength int `json:"maxLength,omitempty"`
 }
 
+const (
+	// DefaultHistoryLimit is the default number of past reconciliations to keep.
+	DefaultHistoryLimit = 10
+
+	// DefaultMaxRetryInterval is the default maximum interval at which to retry failed reconciliations.
+	DefaultMaxRetryInterval = time.Hour
+)
+
 // Reconciliation defines how to reconcile the Konfiguration.
 type Reconciliation struct {
 	// The interval at which to reconcile the Konfiguration.
@@ -156,17 +394,232 @@ type Reconciliation struct {
 	// +required
 	Interval metav1.Duration `json:"interval"`
 
-	// The interval at which to retry a previously failed reconciliation.
+	// The interval at which to retry a previously failed reconciliation. It doubles with each consecutive failure,
+	// up to MaxRetryInterval. Defaults to Interval.
 	// +kubebuilder:validation:Type=string
 	// +kubebuilder:validation:Pattern="^([0-9]+(\\.[0-9]+)?(ms|s|m|h))+$"
 	// +optional
 	RetryInterval *metav1.Duration `json:"retryInterval,omitempty"`
 
+	// The maximum interval at which to retry consecutively failed reconciliations. Defaults to 1h.
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:Pattern="^([0-9]+(\\.[0-9]+)?(ms|s|m|h))+$"
+	// +optional
+	MaxRetryInterval *metav1.Duration `json:"maxRetryInterval,omitempty"`
+
 	// This flag tells the controller to suspend rendering the Konfiguration.
 	// It does not apply to already started executions. Default is false.
 	// +kubebuilder:default:=false
 	// +optional
 	Suspend bool `json:"suspend,omitempty"`
+
+	// The number of past reconciliations to keep in .status.history. Defaults to 10.
+	// +kubebuilder:default:=10
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	HistoryLimit *int32 `json:"historyLimit,omitempty"`
+
+	// The maximum duration of a single reconciliation, including fetching the source and the schema, decrypting,
+	// rendering and applying. Defaults to the --reconcile-timeout flag of the operator, 0s disables the timeout.
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:Pattern="^([0-9]+(\\.[0-9]+)?(ms|s|m|h))+$"
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+
+	// FailurePolicy defines how failed iterations affect the others. Continue applies every iteration independently,
+	// FailFast stops starting iterations at the first failure and Atomic only applies the iterations when all of them
+	// rendered and passed the pre-flight checks. Defaults to Continue.
+	// +kubebuilder:validation:Enum=Continue;FailFast;Atomic
+	// +kubebuilder:default:=Continue
+	// +optional
+	FailurePolicy FailurePolicy `json:"failurePolicy,omitempty"`
+
+	// Rollout stages new revisions of the source across the iterations in waves. Without it, a new revision is
+	// applied to all iterations at once.
+	// +optional
+	Rollout *Rollout `json:"rollout,omitempty"`
+
+	// Approval defines whether new revisions of the source are applied right away or only once approved. Manual
+	// renders new revisions and records them in .status.pendingRevision, they are applied once the
+	// `configuration.giantswarm.io/approved-revision` annotation of the Konfiguration is set to them.
+	// Defaults to Automatic.
+	// +kubebuilder:validation:Enum=Automatic;Manual
+	// +kubebuilder:default:=Automatic
+	// +optional
+	Approval ApprovalPolicy `json:"approval,omitempty"`
+
+	// Windows restrict when new revisions of the source are applied. New revisions are applied while any Allow window
+	// is open, or at any time without Allow windows, unless a Deny window is open. Until then, they are rendered and
+	// recorded in .status.pendingRevision.
+	// +optional
+	Windows []MaintenanceWindow `json:"windows,omitempty"`
+}
+
+// MaintenanceWindow defines a recurring window of time in which applying new revisions is allowed or denied.
+type MaintenanceWindow struct {
+	// Type tells whether new revisions may be applied while the window is open. Defaults to Allow.
+	// +kubebuilder:validation:Enum=Allow;Deny
+	// +kubebuilder:default:=Allow
+	// +optional
+	Type WindowType `json:"type,omitempty"`
+
+	// The cron expression of the times the window opens at, made of five fields: minute, hour, day of month, month
+	// and day of week, e.g. `0 9 * * 1-5`.
+	// +kubebuilder:validation:MinLength=1
+	// +required
+	Schedule string `json:"schedule"`
+
+	// How long the window stays open each time it opens.
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:Pattern="^([0-9]+(\\.[0-9]+)?(ms|s|m|h))+$"
+	// +required
+	Duration metav1.Duration `json:"duration"`
+
+	// The IANA time zone the schedule is evaluated in, e.g. `Europe/Berlin`. Defaults to UTC.
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
+}
+
+// WindowType defines whether a maintenance window allows or denies applying new revisions.
+type WindowType string
+
+const (
+	// WindowTypeAllow only allows applying new revisions while the window is open.
+	WindowTypeAllow WindowType = "Allow"
+
+	// WindowTypeDeny denies applying new revisions while the window is open.
+	WindowTypeDeny WindowType = "Deny"
+)
+
+// Rollout defines how to stage new revisions of the source across the iterations.
+type Rollout struct {
+	// The waves to roll out new revisions in, in order. A wave is only started once the previous one was applied
+	// without failures, soaked and passed its health checks. Iterations are rolled out in the first wave selecting
+	// them, iterations not selected by any wave in a final wave.
+	// +kubebuilder:validation:MinItems=1
+	// +required
+	Waves []RolloutWave `json:"waves"`
+}
+
+// RolloutWave defines a group of iterations rolled out together.
+type RolloutWave struct {
+	// Name of the wave, used in the status and events.
+	// +kubebuilder:validation:MinLength=1
+	// +required
+	Name string `json:"name"`
+
+	// Selects iterations by their names.
+	// +optional
+	Iterations []string `json:"iterations,omitempty"`
+
+	// Selects iterations by their labels.
+	// +optional
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+
+	// The time to wait after the wave was applied before checking its health and starting the next wave.
+	// Defaults to 0s.
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:Pattern="^([0-9]+(\\.[0-9]+)?(ms|s|m|h))+$"
+	// +optional
+	SoakTime *metav1.Duration `json:"soakTime,omitempty"`
+
+	// Workloads that must be ready once the soak time passed, checked for each iteration of the wave. The rollout
+	// halts otherwise.
+	// +optional
+	HealthChecks []HealthCheck `json:"healthChecks,omitempty"`
+}
+
+// GetSoakTime returns the time to wait after the wave was applied.
+func (w *RolloutWave) GetSoakTime() time.Duration {
+	if w.SoakTime == nil {
+		return 0
+	}
+
+	return w.SoakTime.Duration
+}
+
+// HealthCheck defines a workload that must be ready for a wave to pass. Deployments must have all their replicas
+// updated and available, Flux HelmReleases must be ready and Giant Swarm App CRs must be deployed.
+type HealthCheck struct {
+	// Kind of the workload to check.
+	// +required
+	Kind RolloutTriggerKind `json:"kind"`
+
+	// Name of the workload to check. May reference the resolved variables of the iteration,
+	// e.g. `${konfiguration.iteration}`.
+	// +kubebuilder:validation:MinLength=1
+	// +required
+	Name string `json:"name"`
+
+	// Namespace of the workload to check. May reference the resolved variables of the iteration.
+	// Defaults to the destination namespace of the iteration.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// FailurePolicy defines how failed iterations affect the other iterations of a reconciliation.
+type FailurePolicy string
+
+const (
+	// FailurePolicyContinue reconciles every iteration independently, regardless of the others failing.
+	FailurePolicyContinue FailurePolicy = "Continue"
+
+	// FailurePolicyFailFast stops starting iterations once one failed. Iterations already started still complete.
+	FailurePolicyFailFast FailurePolicy = "FailFast"
+
+	// FailurePolicyAtomic renders and pre-flight checks all iterations first and applies them only if none failed.
+	FailurePolicyAtomic FailurePolicy = "Atomic"
+)
+
+// ApprovalPolicy defines whether new revisions of the source need to be approved before being applied.
+type ApprovalPolicy string
+
+const (
+	// ApprovalPolicyAutomatic applies new revisions as soon as they are fetched.
+	ApprovalPolicyAutomatic ApprovalPolicy = "Automatic"
+
+	// ApprovalPolicyManual only applies new revisions once they are approved by annotating the Konfiguration.
+	ApprovalPolicyManual ApprovalPolicy = "Manual"
+)
+
+// GetHistoryLimit returns the number of past reconciliations to keep.
+func (r *Reconciliation) GetHistoryLimit() int {
+	if r.HistoryLimit == nil {
+		return DefaultHistoryLimit
+	}
+
+	return int(*r.HistoryLimit)
+}
+
+// GetRetryInterval returns the interval at which to retry a failed reconciliation, falling back to the interval.
+func (r *Reconciliation) GetRetryInterval() time.Duration {
+	if r.RetryInterval == nil {
+		return r.Interval.Duration
+	}
+
+	return r.RetryInterval.Duration
+}
+
+// GetMaxRetryInterval returns the maximum interval at which to retry consecutively failed reconciliations. It is never
+// shorter than the retry interval.
+func (r *Reconciliation) GetMaxRetryInterval() time.Duration {
+	maxRetryInterval := DefaultMaxRetryInterval
+	if r.MaxRetryInterval != nil {
+		maxRetryInterval = r.MaxRetryInterval.Duration
+	}
+
+	return max(maxRetryInterval, r.GetRetryInterval())
+}
+
+// GetTimeout returns the maximum duration of a single reconciliation, falling back to the given default.
+// Zero means no timeout.
+func (r *Reconciliation) GetTimeout(defaultTimeout time.Duration) time.Duration {
+	if r.Timeout == nil {
+		return defaultTimeout
+	}
+
+	return r.Timeout.Duration
 }
 
 // Sources define where to find the source of the konfiguration that needs to be rendered.
@@ -213,28 +666,331 @@ type KonfigurationStatus struct {
 	// +optional
 	LastReconciledAt string `json:"lastReconciledAt,omitempty"`
 
+	// The number of reconciliations failed in a row since the last successful one. Failed reconciliations are retried
+	// with a backoff based on it.
+	// +optional
+	ConsecutiveFailures int32 `json:"consecutiveFailures,omitempty"`
+
 	// +optional
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
 
-	// The list of failed iterations during the last full reconciliation.
-	Failed []FailedIteration `json:"failed,omitempty"`
+	// Summary of the results of the iterations during the last full reconciliation.
+	// +optional
+	Summary IterationSummary `json:"summary,omitempty"`
+
+	// Reference to the ConfigMap holding the report with the full per-iteration details of the last full
+	// reconciliation.
+	// +optional
+	Report *ReportReference `json:"report,omitempty"`
+
+	// The past reconciliations, newest first, bounded by .spec.reconciliation.historyLimit.
+	// +optional
+	History []ReconciliationRun `json:"history,omitempty"`
 
-	// The list of rendered manifests that were not applied during the last full reconciliation,
-	// because their reconciliation is disabled via the `configuration.giantswarm.io/reconcile: disabled` label.
-	Disabled []DisabledIteration `json:"disabled,omitempty"`
+	// The progress of the staged rollout of the last attempted revision, set when .spec.reconciliation.rollout is.
+	// +optional
+	Rollout *RolloutStatus `json:"rollout,omitempty"`
+
+	// The revision rendered but not applied yet, as it awaits approval or is held back by the change freeze of the
+	// operator or by .spec.reconciliation.windows.
+	// +optional
+	PendingRevision *PendingRevision `json:"pendingRevision,omitempty"`
 }
 
-// FailedIteration defines information of a single failed iteration.
-type FailedIteration struct {
+// PendingRevision defines a revision held back and the changes applying it would make.
+type PendingRevision struct {
+	// The revision held back.
+	// +required
+	Revision string `json:"revision"`
+
+	// The time the revision was first rendered.
+	// +required
+	Since metav1.Time `json:"since"`
+
+	// Why the revision is not applied yet: ApprovalPending, ChangeFreeze or OutsideMaintenanceWindow.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// The time the next maintenance window allowing to apply the revision opens, when it is held back by the windows.
+	// +optional
+	NextWindowAt *metav1.Time `json:"nextWindowAt,omitempty"`
+
+	// The number of iterations whose manifests would be created.
+	Created int `json:"created"`
+
+	// The number of iterations whose manifests would be updated, but none created.
+	Updated int `json:"updated"`
+
+	// The number of iterations whose manifests would not change.
+	Unchanged int `json:"unchanged"`
+
+	// The number of iterations that failed to render or to pass the pre-flight checks.
+	Failed int `json:"failed"`
+}
+
+// RolloutPhase defines the phase of a staged rollout.
+// +kubebuilder:validation:Enum=Progressing;Halted;Completed
+type RolloutPhase string
+
+const (
+	// RolloutPhaseProgressing means the revision is being rolled out wave by wave.
+	RolloutPhaseProgressing RolloutPhase = "Progressing"
+
+	// RolloutPhaseHalted means a wave failed its health checks, no further waves are started for the revision.
+	RolloutPhaseHalted RolloutPhase = "Halted"
+
+	// RolloutPhaseCompleted means the revision is rolled out to all waves.
+	RolloutPhaseCompleted RolloutPhase = "Completed"
+)
+
+// RolloutStatus defines the progress of the staged rollout of a revision.
+type RolloutStatus struct {
+	// The revision being rolled out.
+	// +required
+	Revision string `json:"revision"`
+
+	// The revision the iterations of the waves not started yet are still at.
+	// +optional
+	PreviousRevision string `json:"previousRevision,omitempty"`
+
+	// +required
+	Phase RolloutPhase `json:"phase"`
+
+	// The name of the wave being rolled out, or of the last wave once completed.
+	// +optional
+	Wave string `json:"wave,omitempty"`
+
+	// The number of waves rolled out completely.
+	CompletedWaves int32 `json:"completedWaves"`
+
+	// The number of waves.
+	TotalWaves int32 `json:"totalWaves"`
+
+	// The time the current wave was applied without failures, its soak time started then.
+	// +optional
+	WaveAppliedAt *metav1.Time `json:"waveAppliedAt,omitempty"`
+
+	// Human readable details of the phase.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// ReconciliationRun defines the record of a single past reconciliation.
+type ReconciliationRun struct {
+	// The revision of the source that was reconciled. Empty when the source could not be fetched.
+	// +optional
+	Revision string `json:"revision,omitempty"`
+
+	// The SHA-256 digest of the schema that was used to render. Empty when the schema could not be fetched.
+	// +optional
+	SchemaDigest string `json:"schemaDigest,omitempty"`
+
+	// The time the reconciliation started.
+	// +required
+	StartedAt metav1.Time `json:"startedAt"`
+
+	// The time the reconciliation finished.
+	// +required
+	FinishedAt metav1.Time `json:"finishedAt"`
+
+	// The reason of the Ready condition set by the reconciliation.
+	// +required
+	Reason string `json:"reason"`
+
+	// The number of iterations.
+	Total int `json:"total"`
+
+	// The number of iterations with manifests created.
+	Created int `json:"created"`
+
+	// The number of iterations with manifests updated, but none created.
+	Updated int `json:"updated"`
+
+	// The number of iterations that failed to render or apply.
+	Failed int `json:"failed"`
+
+	// The number of iterations with manifests disabled for reconciliation.
+	Disabled int `json:"disabled"`
+
+	// The number of iterations not rendered again, as their inputs and manifests did not change since their last
+	// successful reconciliation.
+	// +optional
+	Skipped int `json:"skipped,omitempty"`
+}
+
+// ReportReference defines the reference of the ConfigMap holding the report of a Konfiguration.
+type ReportReference struct {
+	// Name of the ConfigMap in the namespace of the Konfiguration.
+	// +required
+	Name string `json:"name"`
+
+	// Key of the report in the ConfigMap, `report.json` in its data or `report.json.gz` in its binary data
+	// for large reports.
+	// +required
+	Key string `json:"key"`
+}
+
+// IterationSummary defines the number of iterations per result during the last full reconciliation.
+type IterationSummary struct {
+	// The number of iterations.
+	Total int `json:"total"`
+
+	// The number of iterations rendered and applied successfully.
+	Succeeded int `json:"succeeded"`
+
+	// The number of iterations that failed to render or apply.
+	Failed int `json:"failed"`
+
+	// The number of iterations with manifests disabled for reconciliation.
+	Disabled int `json:"disabled"`
+
+	// The number of iterations waiting for a later wave of the rollout or for the approval of a new revision.
+	// +optional
+	Pending int `json:"pending,omitempty"`
+}
+
+// IterationResult defines the result of the reconciliation of a single iteration.
+// +kubebuilder:validation:Enum=Succeeded;Failed;Disabled;Pending
+type IterationResult string
+
+const (
+	IterationResultSucceeded IterationResult = "Succeeded"
+	IterationResultFailed    IterationResult = "Failed"
+	IterationResultDisabled  IterationResult = "Disabled"
+	IterationResultPending   IterationResult = "Pending"
+)
+
+// IterationStatus defines the observed state of a single iteration.
+type IterationStatus struct {
 	// The name of the iteration, that is the map key under .spec.targets.iterations.
 	// +kubebuilder:validation:Type=string
 	// +required
-	Name string `json:"appName"`
+	Name string `json:"name"`
+
+	// Reference to the ConfigMap holding the rendered data of the iteration.
+	// +optional
+	ConfigMap *TargetReference `json:"configMap,omitempty"`
+
+	// Reference to the Secret holding the rendered data of the iteration.
+	// Not set when the Secret is not rendered or has no content.
+	// +optional
+	Secret *TargetReference `json:"secret,omitempty"`
+
+	// Checksum of the rendered data of the iteration that was last applied and propagated to the rollout triggers.
+	// +optional
+	Checksum string `json:"checksum,omitempty"`
+
+	// Outcome of the last apply of the rendered manifests of the iteration. Created when any manifest was created,
+	// Updated when any was updated and Unchanged when all of them were up-to-date and thus not written.
+	// +optional
+	Outcome ApplyOutcome `json:"outcome,omitempty"`
+
+	// Result of the last reconciliation of the iteration. Disabled when any of its manifests is disabled
+	// for reconciliation.
+	// +optional
+	Result IterationResult `json:"result,omitempty"`
+
+	// The revision of the source last applied for the iteration.
+	// +optional
+	LastAppliedRevision string `json:"lastAppliedRevision,omitempty"`
+
+	// The classified cause of the failure of the last reconciliation of the iteration.
+	// +optional
+	LastError *IterationError `json:"lastError,omitempty"`
+
+	// Fingerprint of the inputs the iteration was last rendered with: the source revision, the schema digest, the
+	// Konfiguration generation and the resolved variables.
+	// +optional
+	Fingerprint string `json:"fingerprint,omitempty"`
+
+	// Whether rendering was skipped during the last reconciliation, as the fingerprint matched the last successful
+	// one and the manifests still held the rendered data.
+	// +optional
+	RenderSkipped bool `json:"renderSkipped,omitempty"`
+
+	// The time it took to render the iteration during its last reconciliation.
+	// +optional
+	RenderDuration *metav1.Duration `json:"renderDuration,omitempty"`
+
+	// The time it took to apply the rendered manifests of the iteration during its last reconciliation.
+	// +optional
+	ApplyDuration *metav1.Duration `json:"applyDuration,omitempty"`
+
+	// The changes applying the revision held back would make to the manifests of the iteration.
+	// +optional
+	PendingChange *PendingChange `json:"pendingChange,omitempty"`
+}
+
+// PendingChange defines the changes applying a revision held back would make to the manifests of an iteration.
+type PendingChange struct {
+	// The revision held back.
+	// +required
+	Revision string `json:"revision"`
+
+	// Whether any manifest would be created, updated or none would change.
+	// +required
+	Outcome ApplyOutcome `json:"outcome"`
+
+	// The keys of the rendered data that would be added, removed or modified, prefixed with the kind of their
+	// manifest, e.g. `ConfigMap/configmap-values.yaml`.
+	// +optional
+	ChangedKeys []string `json:"changedKeys,omitempty"`
+}
+
+// IterationError defines the classified cause of the failure of an iteration.
+type IterationError struct {
+	// Reason classifies the failure, e.g. MissingFile, InvalidYAML, DecryptionKeyMissing or OwnershipConflict.
+	// +required
+	Reason string `json:"reason"`
 
 	// Informational message on the cause of the failure.
-	// +kubebuilder:validation:Type=string
 	// +required
 	Message string `json:"message"`
+
+	// The path of the file in the source the failure originates from, if known.
+	// +optional
+	File string `json:"file,omitempty"`
+
+	// The line in the file the failure originates from, if known.
+	// +optional
+	Line int `json:"line,omitempty"`
+}
+
+// ApplyOutcome defines the outcome of applying rendered Kubernetes manifests.
+// +kubebuilder:validation:Enum=Created;Updated;Unchanged
+type ApplyOutcome string
+
+const (
+	ApplyOutcomeCreated   ApplyOutcome = "Created"
+	ApplyOutcomeUpdated   ApplyOutcome = "Updated"
+	ApplyOutcomeUnchanged ApplyOutcome = "Unchanged"
+)
+
+// Merge returns the more significant of the two outcomes, Created over Updated over Unchanged.
+// Empty outcomes belong to manifests that were not applied and are ignored.
+func (o ApplyOutcome) Merge(other ApplyOutcome) ApplyOutcome {
+	for _, outcome := range []ApplyOutcome{ApplyOutcomeCreated, ApplyOutcomeUpdated, ApplyOutcomeUnchanged} {
+		if o == outcome || other == outcome {
+			return outcome
+		}
+	}
+
+	return ""
+}
+
+// TargetReference defines the reference of a single rendered Kubernetes manifest.
+type TargetReference struct {
+	// Name of the resource.
+	// +required
+	Name string `json:"name"`
+
+	// Namespace of the resource.
+	// +required
+	Namespace string `json:"namespace"`
+
+	// Checksum of the data of the resource, also found in its `configuration.giantswarm.io/checksum` annotation.
+	// +optional
+	Checksum string `json:"checksum,omitempty"`
 }
 
 // DisabledIteration defines information on managed Kubernetes manifests whose state is not currently enforced
@@ -279,6 +1035,9 @@ type DisabledIterationTarget struct {
 // +kubebuilder:printcolumn:name="SchemaNamespace",type="string",JSONPath=".spec.targets.schema.reference.namespace",description=""
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description=""
 // +kubebuilder:printcolumn:name="Suspended",type="boolean",JSONPath=".spec.reconciliation.suspend",description=""
+// +kubebuilder:printcolumn:name="Iterations",type="integer",JSONPath=".status.summary.total",description=""
+// +kubebuilder:printcolumn:name="Succeeded",type="integer",JSONPath=".status.summary.succeeded",description=""
+// +kubebuilder:printcolumn:name="Failed",type="integer",JSONPath=".status.summary.failed",description=""
 // +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].status",description=""
 // +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].message",description=""
 type Konfiguration struct {
@@ -0,0 +1,28 @@
+package v1alpha1
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestApplyOutcomeMerge(t *testing.T) {
+	testCases := []struct {
+		a        ApplyOutcome
+		b        ApplyOutcome
+		expected ApplyOutcome
+	}{
+		{a: "", b: "", expected: ""},
+		{a: "", b: ApplyOutcomeUnchanged, expected: ApplyOutcomeUnchanged},
+		{a: ApplyOutcomeUnchanged, b: ApplyOutcomeUpdated, expected: ApplyOutcomeUpdated},
+		{a: ApplyOutcomeCreated, b: ApplyOutcomeUpdated, expected: ApplyOutcomeCreated},
+		{a: ApplyOutcomeUnchanged, b: "", expected: ApplyOutcomeUnchanged},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("case %d: %s and %s", i, tc.a, tc.b), func(t *testing.T) {
+			if result := tc.a.Merge(tc.b); result != tc.expected {
+				t.Fatalf("expected result: %s, got: %s", tc.expected, result)
+			}
+		})
+	}
+}
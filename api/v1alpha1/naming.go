@@ -0,0 +1,65 @@
+package v1alpha1
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+const (
+	// DefaultNameMaxLength is the maximum length of a DNS subdomain name, ConfigMaps and Secrets must follow it.
+	DefaultNameMaxLength = 253
+
+	// IterationNameTemplateKey is the key of the iteration name in the naming template data.
+	IterationNameTemplateKey = "iteration"
+
+	nameHashLength = 8
+)
+
+func renderNameTemplate(text, iterationName string, variables map[string]string) (string, error) {
+	tmpl, err := template.New("naming").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse naming template: %w", err)
+	}
+
+	data := make(map[string]string, len(variables)+1)
+	for name, value := range variables {
+		data[name] = value
+	}
+	data[IterationNameTemplateKey] = iterationName
+
+	var buffer bytes.Buffer
+	if err = tmpl.Execute(&buffer, data); err != nil {
+		return "", fmt.Errorf("failed to execute naming template: %w", err)
+	}
+
+	return strings.TrimSpace(buffer.String()), nil
+}
+
+// truncateName keeps names within the given length by cutting them and appending a short hash of the full name,
+// so that distinct long names sharing the same beginning still result in distinct names.
+func truncateName(name string, maxLength int) string {
+	if len(name) <= maxLength {
+		return name
+	}
+
+	sum := sha256.Sum256([]byte(name))
+	hash := hex.EncodeToString(sum[:])[:nameHashLength]
+
+	truncated := strings.TrimRight(name[:maxLength-nameHashLength-1], "-.")
+
+	return truncated + "-" + hash
+}
+
+func validateName(name string) (string, error) {
+	if errs := validation.IsDNS1123Subdomain(name); len(errs) > 0 {
+		return "", fmt.Errorf("invalid name \"%s\": %s", name, strings.Join(errs, ", "))
+	}
+
+	return name, nil
+}
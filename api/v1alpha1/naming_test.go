@@ -0,0 +1,140 @@
+package v1alpha1
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestNamingOptionsRender(t *testing.T) {
+	longIteration := strings.Repeat("a", 70)
+
+	testCases := []struct {
+		name          string
+		naming        NamingOptions
+		iteration     string
+		variables     map[string]string
+		expected      string
+		expectedError string
+	}{
+		{
+			name:      "no naming options",
+			naming:    NamingOptions{},
+			iteration: "app-operator",
+			expected:  "app-operator",
+		},
+		{
+			name:      "prefix and suffix with separator",
+			naming:    NamingOptions{Prefix: "golem", Suffix: "konfiguration", UseSeparator: true},
+			iteration: "app-operator",
+			expected:  "golem-app-operator-konfiguration",
+		},
+		{
+			name:      "prefix and suffix without separator",
+			naming:    NamingOptions{Prefix: "golem", Suffix: "konfiguration"},
+			iteration: "app-operator",
+			expected:  "golemapp-operatorkonfiguration",
+		},
+		{
+			name:      "template takes precedence over prefix and suffix",
+			naming:    NamingOptions{Prefix: "golem", Suffix: "konfiguration", UseSeparator: true, Template: "{{ .cluster }}-{{ .iteration }}-config"},
+			iteration: "app-operator",
+			variables: map[string]string{"cluster": "golem"},
+			expected:  "golem-app-operator-config",
+		},
+		{
+			name:      "template can access variables with dots via index",
+			naming:    NamingOptions{Template: `{{ index . "konfiguration.namespace" }}-{{ .iteration }}`},
+			iteration: "app-operator",
+			variables: map[string]string{"konfiguration.namespace": "giantswarm"},
+			expected:  "giantswarm-app-operator",
+		},
+		{
+			name:      "iteration shadows variable with the same name",
+			naming:    NamingOptions{Template: "{{ .iteration }}"},
+			iteration: "app-operator",
+			variables: map[string]string{"iteration": "something-else"},
+			expected:  "app-operator",
+		},
+		{
+			name:          "template referencing missing variable",
+			naming:        NamingOptions{Template: "{{ .cluster }}-{{ .iteration }}"},
+			iteration:     "app-operator",
+			expectedError: `failed to execute naming template: template: naming:1:3: executing "naming" at <.cluster>: map has no entry for key "cluster"`,
+		},
+		{
+			name:          "malformed template",
+			naming:        NamingOptions{Template: "{{ .iteration "},
+			iteration:     "app-operator",
+			expectedError: "failed to parse naming template: template: naming:1: unclosed action",
+		},
+		{
+			name:          "template rendering invalid name",
+			naming:        NamingOptions{Template: "{{ .cluster }}_{{ .iteration }}"},
+			iteration:     "app-operator",
+			variables:     map[string]string{"cluster": "Golem"},
+			expectedError: `invalid name "Golem_app-operator": a lowercase RFC 1123 subdomain must consist of lower case alphanumeric characters, '-' or '.', and must start and end with an alphanumeric character (e.g. 'example.com', regex used for validation is '[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*')`,
+		},
+		{
+			name:      "name within max length is kept",
+			naming:    NamingOptions{MaxLength: 63},
+			iteration: strings.Repeat("a", 63),
+			expected:  strings.Repeat("a", 63),
+		},
+		{
+			name:      "name over max length is truncated with hash",
+			naming:    NamingOptions{Suffix: "konfiguration", UseSeparator: true, MaxLength: 63},
+			iteration: longIteration,
+			expected:  strings.Repeat("a", 54) + "-" + truncatedNameHash(longIteration+"-konfiguration"),
+		},
+		{
+			name:      "separators are trimmed before the hash",
+			naming:    NamingOptions{MaxLength: 16},
+			iteration: "abcdef-.ghijklmnopqrstuvwxyz",
+			expected:  "abcdef-" + truncatedNameHash("abcdef-.ghijklmnopqrstuvwxyz"),
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("case %d: %s", i, tc.name), func(t *testing.T) {
+			result, err := tc.naming.Render(tc.iteration, tc.variables)
+
+			if tc.expectedError != "" {
+				if err == nil {
+					t.Fatalf("expected error: %s, got none", tc.expectedError)
+				}
+
+				if err.Error() != tc.expectedError {
+					t.Fatalf("error does not match, expected: %s, got: %s", tc.expectedError, err.Error())
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if result != tc.expected {
+				t.Fatalf("result does not match, expected: %s, got: %s", tc.expected, result)
+			}
+
+			maxLength := tc.naming.MaxLength
+			if maxLength == 0 {
+				maxLength = DefaultNameMaxLength
+			}
+
+			if len(result) > maxLength {
+				t.Fatalf("result is longer than %d characters: %s", maxLength, result)
+			}
+		})
+	}
+}
+
+func truncatedNameHash(name string) string {
+	sum := sha256.Sum256([]byte(name))
+
+	return hex.EncodeToString(sum[:])[:8]
+}
@@ -49,6 +49,14 @@ func (in *Defaults) DeepCopy() *Defaults {
 func (in *Destination) DeepCopyInto(out *Destination) {
 	*out = *in
 	out.Naming = in.Naming
+	in.Output.DeepCopyInto(&out.Output)
+	if in.RolloutTriggers != nil {
+		in, out := &in.RolloutTriggers, &out.RolloutTriggers
+		*out = make([]RolloutTrigger, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Destination.
@@ -93,47 +101,67 @@ func (in *DisabledIterationTarget) DeepCopy() *DisabledIterationTarget {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FailedIteration) DeepCopyInto(out *FailedIteration) {
+func (in *FluxSource) DeepCopyInto(out *FluxSource) {
 	*out = *in
+	out.GitRepository = in.GitRepository
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FailedIteration.
-func (in *FailedIteration) DeepCopy() *FailedIteration {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FluxSource.
+func (in *FluxSource) DeepCopy() *FluxSource {
 	if in == nil {
 		return nil
 	}
-	out := new(FailedIteration)
+	out := new(FluxSource)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FluxSource) DeepCopyInto(out *FluxSource) {
+func (in *FluxSourceGitRepository) DeepCopyInto(out *FluxSourceGitRepository) {
 	*out = *in
-	out.GitRepository = in.GitRepository
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FluxSource.
-func (in *FluxSource) DeepCopy() *FluxSource {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FluxSourceGitRepository.
+func (in *FluxSourceGitRepository) DeepCopy() *FluxSourceGitRepository {
 	if in == nil {
 		return nil
 	}
-	out := new(FluxSource)
+	out := new(FluxSourceGitRepository)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FluxSourceGitRepository) DeepCopyInto(out *FluxSourceGitRepository) {
+func (in *HealthCheck) DeepCopyInto(out *HealthCheck) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FluxSourceGitRepository.
-func (in *FluxSourceGitRepository) DeepCopy() *FluxSourceGitRepository {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HealthCheck.
+func (in *HealthCheck) DeepCopy() *HealthCheck {
 	if in == nil {
 		return nil
 	}
-	out := new(FluxSourceGitRepository)
+	out := new(HealthCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImmutableOutput) DeepCopyInto(out *ImmutableOutput) {
+	*out = *in
+	if in.Retain != nil {
+		in, out := &in.Retain, &out.Retain
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImmutableOutput.
+func (in *ImmutableOutput) DeepCopy() *ImmutableOutput {
+	if in == nil {
+		return nil
+	}
+	out := new(ImmutableOutput)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -146,6 +174,18 @@ func (in *Iteration) DeepCopyInto(out *Iteration) {
 		*out = make([]NameValuePair, len(*in))
 		copy(*out, *in)
 	}
+	if in.Destination != nil {
+		in, out := &in.Destination, &out.Destination
+		*out = new(IterationDestination)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Iteration.
@@ -158,6 +198,101 @@ func (in *Iteration) DeepCopy() *Iteration {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IterationDestination) DeepCopyInto(out *IterationDestination) {
+	*out = *in
+	if in.Naming != nil {
+		in, out := &in.Naming, &out.Naming
+		*out = new(NamingOptions)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IterationDestination.
+func (in *IterationDestination) DeepCopy() *IterationDestination {
+	if in == nil {
+		return nil
+	}
+	out := new(IterationDestination)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IterationError) DeepCopyInto(out *IterationError) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IterationError.
+func (in *IterationError) DeepCopy() *IterationError {
+	if in == nil {
+		return nil
+	}
+	out := new(IterationError)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IterationStatus) DeepCopyInto(out *IterationStatus) {
+	*out = *in
+	if in.ConfigMap != nil {
+		in, out := &in.ConfigMap, &out.ConfigMap
+		*out = new(TargetReference)
+		**out = **in
+	}
+	if in.Secret != nil {
+		in, out := &in.Secret, &out.Secret
+		*out = new(TargetReference)
+		**out = **in
+	}
+	if in.LastError != nil {
+		in, out := &in.LastError, &out.LastError
+		*out = new(IterationError)
+		**out = **in
+	}
+	if in.RenderDuration != nil {
+		in, out := &in.RenderDuration, &out.RenderDuration
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.ApplyDuration != nil {
+		in, out := &in.ApplyDuration, &out.ApplyDuration
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.PendingChange != nil {
+		in, out := &in.PendingChange, &out.PendingChange
+		*out = new(PendingChange)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IterationStatus.
+func (in *IterationStatus) DeepCopy() *IterationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(IterationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IterationSummary) DeepCopyInto(out *IterationSummary) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IterationSummary.
+func (in *IterationSummary) DeepCopy() *IterationSummary {
+	if in == nil {
+		return nil
+	}
+	out := new(IterationSummary)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Konfiguration) DeepCopyInto(out *Konfiguration) {
 	*out = *in
@@ -311,7 +446,7 @@ func (in *KonfigurationSchemaStatus) DeepCopy() *KonfigurationSchemaStatus {
 func (in *KonfigurationSpec) DeepCopyInto(out *KonfigurationSpec) {
 	*out = *in
 	in.Targets.DeepCopyInto(&out.Targets)
-	out.Destination = in.Destination
+	in.Destination.DeepCopyInto(&out.Destination)
 	in.Reconciliation.DeepCopyInto(&out.Reconciliation)
 	out.Sources = in.Sources
 }
@@ -336,15 +471,28 @@ func (in *KonfigurationStatus) DeepCopyInto(out *KonfigurationStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	if in.Failed != nil {
-		in, out := &in.Failed, &out.Failed
-		*out = make([]FailedIteration, len(*in))
-		copy(*out, *in)
+	out.Summary = in.Summary
+	if in.Report != nil {
+		in, out := &in.Report, &out.Report
+		*out = new(ReportReference)
+		**out = **in
 	}
-	if in.Disabled != nil {
-		in, out := &in.Disabled, &out.Disabled
-		*out = make([]DisabledIteration, len(*in))
-		copy(*out, *in)
+	if in.History != nil {
+		in, out := &in.History, &out.History
+		*out = make([]ReconciliationRun, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Rollout != nil {
+		in, out := &in.Rollout, &out.Rollout
+		*out = new(RolloutStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PendingRevision != nil {
+		in, out := &in.PendingRevision, &out.PendingRevision
+		*out = new(PendingRevision)
+		(*in).DeepCopyInto(*out)
 	}
 }
 
@@ -358,6 +506,22 @@ func (in *KonfigurationStatus) DeepCopy() *KonfigurationStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceWindow) DeepCopyInto(out *MaintenanceWindow) {
+	*out = *in
+	out.Duration = in.Duration
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceWindow.
+func (in *MaintenanceWindow) DeepCopy() *MaintenanceWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NameValuePair) DeepCopyInto(out *NameValuePair) {
 	*out = *in
@@ -388,6 +552,66 @@ func (in *NamingOptions) DeepCopy() *NamingOptions {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Output) DeepCopyInto(out *Output) {
+	*out = *in
+	if in.Immutable != nil {
+		in, out := &in.Immutable, &out.Immutable
+		*out = new(ImmutableOutput)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Output.
+func (in *Output) DeepCopy() *Output {
+	if in == nil {
+		return nil
+	}
+	out := new(Output)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PendingChange) DeepCopyInto(out *PendingChange) {
+	*out = *in
+	if in.ChangedKeys != nil {
+		in, out := &in.ChangedKeys, &out.ChangedKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PendingChange.
+func (in *PendingChange) DeepCopy() *PendingChange {
+	if in == nil {
+		return nil
+	}
+	out := new(PendingChange)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PendingRevision) DeepCopyInto(out *PendingRevision) {
+	*out = *in
+	in.Since.DeepCopyInto(&out.Since)
+	if in.NextWindowAt != nil {
+		in, out := &in.NextWindowAt, &out.NextWindowAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PendingRevision.
+func (in *PendingRevision) DeepCopy() *PendingRevision {
+	if in == nil {
+		return nil
+	}
+	out := new(PendingRevision)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Raw) DeepCopyInto(out *Raw) {
 	*out = *in
@@ -413,6 +637,31 @@ func (in *Reconciliation) DeepCopyInto(out *Reconciliation) {
 		*out = new(v1.Duration)
 		**out = **in
 	}
+	if in.MaxRetryInterval != nil {
+		in, out := &in.MaxRetryInterval, &out.MaxRetryInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.HistoryLimit != nil {
+		in, out := &in.HistoryLimit, &out.HistoryLimit
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Rollout != nil {
+		in, out := &in.Rollout, &out.Rollout
+		*out = new(Rollout)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Windows != nil {
+		in, out := &in.Windows, &out.Windows
+		*out = make([]MaintenanceWindow, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Reconciliation.
@@ -425,6 +674,23 @@ func (in *Reconciliation) DeepCopy() *Reconciliation {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReconciliationRun) DeepCopyInto(out *ReconciliationRun) {
+	*out = *in
+	in.StartedAt.DeepCopyInto(&out.StartedAt)
+	in.FinishedAt.DeepCopyInto(&out.FinishedAt)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReconciliationRun.
+func (in *ReconciliationRun) DeepCopy() *ReconciliationRun {
+	if in == nil {
+		return nil
+	}
+	out := new(ReconciliationRun)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Remote) DeepCopyInto(out *Remote) {
 	*out = *in
@@ -440,6 +706,117 @@ func (in *Remote) DeepCopy() *Remote {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReportReference) DeepCopyInto(out *ReportReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReportReference.
+func (in *ReportReference) DeepCopy() *ReportReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ReportReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Rollout) DeepCopyInto(out *Rollout) {
+	*out = *in
+	if in.Waves != nil {
+		in, out := &in.Waves, &out.Waves
+		*out = make([]RolloutWave, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Rollout.
+func (in *Rollout) DeepCopy() *Rollout {
+	if in == nil {
+		return nil
+	}
+	out := new(Rollout)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RolloutStatus) DeepCopyInto(out *RolloutStatus) {
+	*out = *in
+	if in.WaveAppliedAt != nil {
+		in, out := &in.WaveAppliedAt, &out.WaveAppliedAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutStatus.
+func (in *RolloutStatus) DeepCopy() *RolloutStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RolloutStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RolloutTrigger) DeepCopyInto(out *RolloutTrigger) {
+	*out = *in
+	if in.Iterations != nil {
+		in, out := &in.Iterations, &out.Iterations
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutTrigger.
+func (in *RolloutTrigger) DeepCopy() *RolloutTrigger {
+	if in == nil {
+		return nil
+	}
+	out := new(RolloutTrigger)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RolloutWave) DeepCopyInto(out *RolloutWave) {
+	*out = *in
+	if in.Iterations != nil {
+		in, out := &in.Iterations, &out.Iterations
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SoakTime != nil {
+		in, out := &in.SoakTime, &out.SoakTime
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.HealthChecks != nil {
+		in, out := &in.HealthChecks, &out.HealthChecks
+		*out = make([]HealthCheck, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutWave.
+func (in *RolloutWave) DeepCopy() *RolloutWave {
+	if in == nil {
+		return nil
+	}
+	out := new(RolloutWave)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Schema) DeepCopyInto(out *Schema) {
 	*out = *in
@@ -487,6 +864,21 @@ func (in *Sources) DeepCopy() *Sources {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TargetReference) DeepCopyInto(out *TargetReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TargetReference.
+func (in *TargetReference) DeepCopy() *TargetReference {
+	if in == nil {
+		return nil
+	}
+	out := new(TargetReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Targets) DeepCopyInto(out *Targets) {
 	*out = *in
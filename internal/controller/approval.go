@@ -0,0 +1,81 @@
+package controller
+
+import (
+	"context"
+
+	v1 "k8s.io/api/core/v1"
+	apiMachineryErrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	konfigurev1alpha1 "github.com/giantswarm/konfigure-operator/api/v1alpha1"
+	"github.com/giantswarm/konfigure-operator/internal/controller/logic"
+)
+
+// previewIterations renders the planned iterations and runs their pre-flight checks, but reports the changes applying
+// them would make instead of applying them. Results are returned in the order of the plans.
+func (r *KonfigurationReconciler) previewIterations(ctx context.Context, env *iterationEnvironment, plans []iterationPlan) []iterationResult {
+	return r.runIterations(ctx, env, len(plans), false, func(i int) iterationResult {
+		return r.previewIteration(ctx, env, plans[i])
+	})
+}
+
+// previewIteration renders a single iteration and compares its manifests with the live ones. The iteration keeps the
+// state of the revision it is at, with the pending change added to it.
+func (r *KonfigurationReconciler) previewIteration(ctx context.Context, env *iterationEnvironment, plan iterationPlan) iterationResult {
+	result := r.prepareIteration(ctx, env, plan)
+	if result.failure != nil {
+		return result
+	}
+
+	// Iterations not rendered again are at the pending revision already.
+	change := &konfigurev1alpha1.PendingChange{Revision: env.revision, Outcome: konfigurev1alpha1.ApplyOutcomeUnchanged}
+	if result.prepared != nil {
+		if err := r.addPendingChanges(ctx, env, result.prepared, change); err != nil {
+			result.failure = logic.ClassifyError(err, logic.ApplyFailedReason)
+			result.prepared = nil
+			return result
+		}
+	}
+
+	status := env.previousStatuses[plan.name]
+	status.Name = plan.name
+	status.Result = konfigurev1alpha1.IterationResultPending
+	status.RenderSkipped = false
+	status.LastError = nil
+	status.PendingChange = change
+
+	result.status = &status
+	result.prepared = nil
+
+	return result
+}
+
+// addPendingChanges adds the changes of the data of the prepared manifests compared to the live ones to the change.
+func (r *KonfigurationReconciler) addPendingChanges(ctx context.Context, env *iterationEnvironment, prepared *preparedIteration, change *konfigurev1alpha1.PendingChange) error {
+	output := env.cr.Spec.Destination.Output
+
+	if output.RendersConfigMap() {
+		live := &v1.ConfigMap{}
+		err := r.Get(ctx, client.ObjectKeyFromObject(prepared.configmap), live)
+		if err != nil && !apiMachineryErrors.IsNotFound(err) {
+			return err
+		}
+
+		logic.AddPendingChange(change, "ConfigMap", err == nil, logic.ChangedConfigMapKeys(live.Data, prepared.configmap.Data))
+	}
+
+	if output.RendersSecret() {
+		live := &v1.Secret{}
+		err := r.Get(ctx, client.ObjectKeyFromObject(prepared.secret), live)
+		if err != nil && !apiMachineryErrors.IsNotFound(err) {
+			return err
+		}
+
+		// Secrets without content are not created.
+		if err == nil || !logic.IsEmptySecretData(prepared.secret.Data) {
+			logic.AddPendingChange(change, "Secret", err == nil, logic.ChangedSecretKeys(live.Data, prepared.secret.Data))
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,299 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/fluxcd/pkg/tar"
+	"golang.org/x/sync/singleflight"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	konfigurev1alpha1 "github.com/giantswarm/konfigure-operator/api/v1alpha1"
+	"github.com/giantswarm/konfigure-operator/internal/controller/logic"
+)
+
+const (
+	DefaultArtifactCacheDir = "/tmp/konfigure-cache/artifacts"
+
+	// DefaultArtifactCacheMaxSize stays below the size limit of the cache volume of the Helm chart, so there is room to
+	// download a new revision before old ones are evicted.
+	DefaultArtifactCacheMaxSize = 24 * 1024 * 1024
+
+	// artifactDownloadTimeout bounds a single download, as downloads are shared and thus not bound to the context of
+	// any reconciliation.
+	artifactDownloadTimeout = 60 * time.Second
+)
+
+// ArtifactCache keeps the extracted source artifacts shared by all Konfigurations, one directory per source and
+// revision. Each revision is downloaded once, however many reconciliations ask for it at the same time. Revisions
+// are never modified once extracted, so they can be read without locking while they are referenced. Unreferenced
+// revisions other than the latest of each source are evicted, oldest first, when the cache grows above its size.
+type ArtifactCache struct {
+	dir     string
+	maxSize int64
+
+	httpClient *http.Client
+	downloads  singleflight.Group
+
+	mutex   sync.Mutex
+	entries map[string]*artifactEntry
+	// latest holds the key of the last downloaded revision of each source.
+	latest map[string]string
+}
+
+type artifactEntry struct {
+	key    string
+	source string
+	dir    string
+	size   int64
+
+	references int
+	lastUsed   time.Time
+}
+
+// Artifact is an extracted revision of a source, referenced until it is released.
+type Artifact struct {
+	Dir      string
+	Revision string
+
+	cache       *ArtifactCache
+	entry       *artifactEntry
+	releaseOnce sync.Once
+}
+
+func NewArtifactCache(dir string, maxSize int64) *ArtifactCache {
+	return &ArtifactCache{
+		dir:        dir,
+		maxSize:    maxSize,
+		httpClient: &http.Client{Timeout: artifactDownloadTimeout},
+		entries:    make(map[string]*artifactEntry),
+		latest:     make(map[string]string),
+	}
+}
+
+// Reset removes everything under the cache directory, as revisions left by a previous run of the operator are not
+// tracked and would never be evicted.
+func (c *ArtifactCache) Reset() error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	clear(c.entries)
+	clear(c.latest)
+
+	if err := os.RemoveAll(c.dir); err != nil {
+		return err
+	}
+
+	return os.MkdirAll(c.dir, 0750)
+}
+
+// Acquire returns the extracted artifact at the given URL of the given source, downloading it when it is not cached
+// yet. The artifact must be released once it is no longer read. The download is shared by all callers asking for the
+// same revision, so it outlives the context of the caller that started it, while each caller only waits for it as
+// long as its own context allows.
+func (c *ArtifactCache) Acquire(ctx context.Context, fluxSource konfigurev1alpha1.FluxSource, url string) (*Artifact, error) {
+	source := path.Join(fluxSource.GitRepository.Namespace, fluxSource.GitRepository.Name)
+	revision := logic.ArtifactRevision(url)
+	key := path.Join(source, revision)
+
+	// Retry when the revision was evicted between being downloaded and being referenced.
+	for {
+		if artifact := c.reference(key, revision); artifact != nil {
+			return artifact, nil
+		}
+
+		download := c.downloads.DoChan(key, func() (any, error) {
+			downloadCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), artifactDownloadTimeout)
+			defer cancel()
+
+			return nil, c.download(downloadCtx, key, source, url)
+		})
+
+		select {
+		case result := <-download:
+			if result.Err != nil {
+				return nil, result.Err
+			}
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// reference references the cached entry of the given key, if there is one.
+func (c *ArtifactCache) reference(key string, revision string) *Artifact {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok {
+		return nil
+	}
+
+	entry.references++
+	entry.lastUsed = time.Now()
+
+	return &Artifact{
+		Dir:      entry.dir,
+		Revision: revision,
+		cache:    c,
+		entry:    entry,
+	}
+}
+
+// download extracts the artifact at the given URL and adds it to the cache as the latest revision of its source, which
+// protects it from eviction until it is referenced.
+func (c *ArtifactCache) download(ctx context.Context, key, source, url string) error {
+	logger := log.FromContext(ctx)
+
+	// Another download of the same revision may have finished since the caller looked it up.
+	c.mutex.Lock()
+	_, cached := c.entries[key]
+	c.mutex.Unlock()
+
+	if cached {
+		return nil
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+
+	response, err := c.httpClient.Do(request)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		_ = response.Body.Close()
+	}()
+
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to download artifact %s: unexpected status: %s", url, response.Status)
+	}
+
+	dir := path.Join(c.dir, key)
+
+	// Extract next to the final directory first, so a failed download never leaves a partial revision behind.
+	if err = os.MkdirAll(path.Dir(dir), 0750); err != nil {
+		return err
+	}
+
+	staging, err := os.MkdirTemp(path.Dir(dir), path.Base(dir)+"-")
+	if err != nil {
+		return err
+	}
+
+	if err = tar.Untar(response.Body, staging); err != nil {
+		_ = os.RemoveAll(staging)
+		return fmt.Errorf("failed to extract artifact %s: %w", url, err)
+	}
+
+	if err = os.RemoveAll(dir); err != nil {
+		_ = os.RemoveAll(staging)
+		return err
+	}
+
+	if err = os.Rename(staging, dir); err != nil {
+		_ = os.RemoveAll(staging)
+		return err
+	}
+
+	size, err := diskUsage(dir)
+	if err != nil {
+		return err
+	}
+
+	logger.Info(fmt.Sprintf("Downloaded artifact of %s to: %s (%d bytes)", source, dir, size))
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.entries[key] = &artifactEntry{
+		key:      key,
+		source:   source,
+		dir:      dir,
+		size:     size,
+		lastUsed: time.Now(),
+	}
+	c.latest[source] = key
+
+	return nil
+}
+
+// Release drops the reference to the artifact and evicts old revisions if the cache is full.
+func (a *Artifact) Release() {
+	a.releaseOnce.Do(func() {
+		a.cache.mutex.Lock()
+		defer a.cache.mutex.Unlock()
+
+		a.entry.references--
+		a.entry.lastUsed = time.Now()
+
+		a.cache.evict()
+	})
+}
+
+// evict removes unreferenced revisions, least recently used first, until the cache fits into its size. The latest
+// revision of each source is kept, as it is the one the next reconciliations will ask for. Callers must hold the
+// mutex of the cache.
+func (c *ArtifactCache) evict() {
+	var usage int64
+	var candidates []*artifactEntry
+	for _, entry := range c.entries {
+		usage += entry.size
+
+		if entry.references == 0 && c.latest[entry.source] != entry.key {
+			candidates = append(candidates, entry)
+		}
+	}
+
+	slices.SortFunc(candidates, func(a, b *artifactEntry) int {
+		return a.lastUsed.Compare(b.lastUsed)
+	})
+
+	for _, entry := range candidates {
+		if usage <= c.maxSize {
+			return
+		}
+
+		// Failing to remove the directory only wastes space until the next reset, the revision is downloaded again
+		// when it is asked for.
+		_ = os.RemoveAll(entry.dir)
+
+		delete(c.entries, entry.key)
+		usage -= entry.size
+	}
+}
+
+func diskUsage(dir string) (int64, error) {
+	var size int64
+
+	err := filepath.WalkDir(dir, func(_ string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if entry.Type().IsRegular() {
+			info, err := entry.Info()
+			if err != nil {
+				return err
+			}
+
+			size += info.Size()
+		}
+
+		return nil
+	})
+
+	return size, err
+}
@@ -0,0 +1,222 @@
+package controller
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	konfigurev1alpha1 "github.com/giantswarm/konfigure-operator/api/v1alpha1"
+)
+
+func artifactTarball(t *testing.T, files map[string]string) []byte {
+	var buf bytes.Buffer
+
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+
+	for name, content := range files {
+		if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0600, Size: int64(len(content)), Typeflag: tar.TypeReg}); err != nil {
+			t.Fatalf("error writing tar header: %v", err)
+		}
+
+		if _, err := tw.Write([]byte(content)); err != nil {
+			t.Fatalf("error writing tar content: %v", err)
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		t.Fatalf("error closing tar writer: %v", err)
+	}
+
+	if err := gz.Close(); err != nil {
+		t.Fatalf("error closing gzip writer: %v", err)
+	}
+
+	return buf.Bytes()
+}
+
+func TestArtifactCacheAcquire(t *testing.T) {
+	var downloads atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		revision := strings.TrimSuffix(path.Base(r.URL.Path), ".tar.gz")
+		if revision == "missing" {
+			http.NotFound(w, r)
+			return
+		}
+
+		downloads.Add(1)
+
+		_, _ = w.Write(artifactTarball(t, map[string]string{"revision.txt": revision}))
+	}))
+	defer server.Close()
+
+	cache := NewArtifactCache(t.TempDir(), DefaultArtifactCacheMaxSize)
+	if err := cache.Reset(); err != nil {
+		t.Fatalf("unexpected error on resetting cache: %v", err)
+	}
+
+	source := konfigurev1alpha1.FluxSource{GitRepository: konfigurev1alpha1.FluxSourceGitRepository{Name: "config", Namespace: "flux-giantswarm"}}
+
+	var wg sync.WaitGroup
+	artifacts := make([]*Artifact, 8)
+	for i := range artifacts {
+		wg.Go(func() {
+			artifact, err := cache.Acquire(context.Background(), source, server.URL+"/gitrepository/flux-giantswarm/config/abc.tar.gz")
+			if err != nil {
+				t.Errorf("unexpected error on acquiring artifact: %v", err)
+				return
+			}
+
+			artifacts[i] = artifact
+		})
+	}
+	wg.Wait()
+
+	if t.Failed() {
+		t.FailNow()
+	}
+
+	if downloads.Load() != 1 {
+		t.Fatalf("expected a single download, got %d", downloads.Load())
+	}
+
+	for _, artifact := range artifacts {
+		if artifact.Revision != "abc" {
+			t.Fatalf("expected revision abc, got %s", artifact.Revision)
+		}
+
+		if artifact.Dir != artifacts[0].Dir {
+			t.Fatalf("expected all artifacts to share %s, got %s", artifacts[0].Dir, artifact.Dir)
+		}
+	}
+
+	content, err := os.ReadFile(path.Join(artifacts[0].Dir, "revision.txt"))
+	if err != nil || string(content) != "abc" {
+		t.Fatalf("expected extracted artifact content abc, got %q: %v", content, err)
+	}
+
+	for _, artifact := range artifacts {
+		artifact.Release()
+	}
+
+	again, err := cache.Acquire(context.Background(), source, server.URL+"/gitrepository/flux-giantswarm/config/abc.tar.gz")
+	if err != nil {
+		t.Fatalf("unexpected error on acquiring artifact: %v", err)
+	}
+	again.Release()
+
+	if downloads.Load() != 1 {
+		t.Fatalf("expected cached artifact to be reused, got %d downloads", downloads.Load())
+	}
+
+	if _, err = cache.Acquire(context.Background(), source, server.URL+"/gitrepository/flux-giantswarm/config/missing.tar.gz"); err == nil {
+		t.Fatalf("expected error on acquiring missing artifact")
+	}
+}
+
+func TestArtifactCacheAcquireOutlivesCancelledCaller(t *testing.T) {
+	started := make(chan struct{})
+	unblock := make(chan struct{})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-unblock
+
+		_, _ = w.Write(artifactTarball(t, map[string]string{"revision.txt": "abc"}))
+	}))
+	defer server.Close()
+
+	cache := NewArtifactCache(t.TempDir(), DefaultArtifactCacheMaxSize)
+	if err := cache.Reset(); err != nil {
+		t.Fatalf("unexpected error on resetting cache: %v", err)
+	}
+
+	source := konfigurev1alpha1.FluxSource{GitRepository: konfigurev1alpha1.FluxSourceGitRepository{Name: "config", Namespace: "flux-giantswarm"}}
+	url := server.URL + "/gitrepository/flux-giantswarm/config/abc.tar.gz"
+
+	// The first caller starts the download and gives up while it is in flight.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancelled := make(chan error, 1)
+	go func() {
+		_, err := cache.Acquire(ctx, source, url)
+		cancelled <- err
+	}()
+
+	<-started
+
+	waiting := make(chan error, 1)
+	go func() {
+		artifact, err := cache.Acquire(context.Background(), source, url)
+		if err == nil {
+			artifact.Release()
+		}
+		waiting <- err
+	}()
+
+	cancel()
+	if err := <-cancelled; !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected cancelled caller to fail with its own context error, got: %v", err)
+	}
+
+	close(unblock)
+	if err := <-waiting; err != nil {
+		t.Fatalf("expected other callers to get the artifact, got: %v", err)
+	}
+}
+
+func TestArtifactCacheEviction(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		revision := strings.TrimSuffix(path.Base(r.URL.Path), ".tar.gz")
+
+		_, _ = w.Write(artifactTarball(t, map[string]string{"revision.txt": strings.Repeat(revision, 100)}))
+	}))
+	defer server.Close()
+
+	// Fits a single revision only.
+	cache := NewArtifactCache(t.TempDir(), 150)
+	if err := cache.Reset(); err != nil {
+		t.Fatalf("unexpected error on resetting cache: %v", err)
+	}
+
+	source := konfigurev1alpha1.FluxSource{GitRepository: konfigurev1alpha1.FluxSourceGitRepository{Name: "config", Namespace: "flux-giantswarm"}}
+
+	acquire := func(revision string) *Artifact {
+		artifact, err := cache.Acquire(context.Background(), source, server.URL+"/"+revision+".tar.gz")
+		if err != nil {
+			t.Fatalf("unexpected error on acquiring artifact: %v", err)
+		}
+
+		return artifact
+	}
+
+	first := acquire("a")
+	second := acquire("b")
+
+	// The first revision is still referenced, so it must be kept.
+	second.Release()
+	if _, err := os.Stat(first.Dir); err != nil {
+		t.Fatalf("expected referenced revision to be kept, got: %v", err)
+	}
+
+	// Unreferenced and not the latest, so it is evicted.
+	first.Release()
+	if _, err := os.Stat(first.Dir); !os.IsNotExist(err) {
+		t.Fatalf("expected old revision to be evicted, got: %v", err)
+	}
+
+	// The latest revision is kept even though it is unreferenced.
+	if _, err := os.Stat(second.Dir); err != nil {
+		t.Fatalf("expected latest revision to be kept, got: %v", err)
+	}
+}
@@ -0,0 +1,681 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	konfigureModel "github.com/giantswarm/konfigure/v2/pkg/model"
+	konfigureService "github.com/giantswarm/konfigure/v2/pkg/service"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	konfigurev1alpha1 "github.com/giantswarm/konfigure-operator/api/v1alpha1"
+	"github.com/giantswarm/konfigure-operator/internal/controller/logic"
+)
+
+// DefaultIterationWorkers is the default number of iterations rendered and applied in parallel.
+const DefaultIterationWorkers = 4
+
+// iterationEnvironment holds what is shared by the iterations of a single reconciliation.
+type iterationEnvironment struct {
+	cr      *konfigurev1alpha1.Konfiguration
+	service *konfigureService.DynamicService
+
+	sourceDir      string
+	schemaFilePath string
+	schemaDigest   string
+	revision       string
+
+	ownershipLabels  map[string]string
+	configMapDataKey string
+	secretDataKey    string
+
+	// previousStatuses are the statuses of the iterations from the report of the previous reconciliation.
+	previousStatuses map[string]konfigurev1alpha1.IterationStatus
+
+	// workers tracks the iteration workers, including those still running after the reconciliation timed out.
+	workers *sync.WaitGroup
+}
+
+// iterationPlan holds the target and the resolved variables of an iteration, ready to be rendered.
+type iterationPlan struct {
+	name            string
+	targetName      string
+	targetNamespace string
+	variables       map[string]string
+
+	// nameMaxLength is the maximum length of the names of the rendered manifests, immutable names must fit it too.
+	nameMaxLength int
+}
+
+// preparedIteration holds the rendered manifests of an iteration that passed the pre-flight checks, ready to be
+// applied.
+type preparedIteration struct {
+	fingerprint string
+
+	configmap         *v1.ConfigMap
+	secret            *v1.Secret
+	configMapChecksum string
+	secretChecksum    string
+}
+
+// iterationResult holds the outcome of rendering and applying a single iteration.
+type iterationResult struct {
+	// status is only set for iterations that did not fail.
+	status  *konfigurev1alpha1.IterationStatus
+	failure *logic.ClassifiedError
+
+	// prepared is only set for iterations that are rendered and pre-flight checked, but not applied yet.
+	prepared *preparedIteration
+
+	disabled []konfigurev1alpha1.DisabledIteration
+
+	renderDuration *metav1.Duration
+	applyDuration  *metav1.Duration
+}
+
+// planIterations resolves the targets and variables of the iterations. Iterations are planned in order, so the
+// first one claiming a target wins consistently, regardless of the order they are rendered in later.
+func (r *KonfigurationReconciler) planIterations(ctx context.Context, env *iterationEnvironment, declared []konfigureModel.Variable, iterationNames []string) ([]iterationPlan, map[string]*logic.ClassifiedError) {
+	logger := log.FromContext(ctx)
+	cr := env.cr
+
+	var plans []iterationPlan
+	failures := make(map[string]*logic.ClassifiedError)
+	claimedTargets := make(map[string]string)
+
+	fail := func(iterationName, targetNamespace string, failure *logic.ClassifiedError) {
+		failures[iterationName] = failure
+
+		RecordRendering(cr, iterationName, targetNamespace, false)
+		r.Recorder.Eventf(cr, v1.EventTypeWarning, logic.RenderFailedReason, "Failed to render iteration %s: %s", iterationName, failure.Error())
+	}
+
+	for _, iterationName := range iterationNames {
+		iteration := cr.Spec.Targets.Iterations[iterationName]
+
+		variables := make(map[string]string)
+
+		for _, defaultVariable := range cr.Spec.Targets.Defaults.Variables {
+			variables[defaultVariable.Name] = defaultVariable.Value
+		}
+
+		for _, valueOverride := range iteration.Variables {
+			variables[valueOverride.Name] = valueOverride.Value
+		}
+
+		targetNamespace := cr.Spec.Destination.RenderNamespace(iteration)
+
+		builtinVariables := logic.GenerateBuiltinVariables(cr.ObjectMeta, iterationName, targetNamespace, env.revision)
+
+		// Templated names depend on the resolved variables, so the target name can only be referenced by other
+		// variables when it is not templated. It is still passed down to the renderer in both cases.
+		templatedName := cr.Spec.Destination.IsTemplatedName(iteration)
+
+		var targetName string
+		var err error
+		if !templatedName {
+			targetName, err = cr.Spec.Destination.RenderName(iterationName, iteration, nil)
+			if err != nil {
+				logger.Error(err, fmt.Sprintf("Failed to render target name for iteration: %s", iterationName))
+
+				fail(iterationName, targetNamespace, logic.NewClassifiedError(logic.InvalidNameReason, err))
+				continue
+			}
+
+			builtinVariables[logic.TargetNameVariable] = targetName
+		}
+
+		resolvedVariables, err := logic.ResolveVariables(variables, builtinVariables)
+		if err != nil {
+			logger.Error(err, fmt.Sprintf("Failed to resolve variables for iteration: %s", iterationName))
+
+			fail(iterationName, targetNamespace, logic.NewClassifiedError(logic.InvalidVariablesReason, err))
+			continue
+		}
+
+		if templatedName {
+			targetName, err = cr.Spec.Destination.RenderName(iterationName, iteration, resolvedVariables)
+			if err != nil {
+				logger.Error(err, fmt.Sprintf("Failed to render target name for iteration: %s", iterationName))
+
+				fail(iterationName, targetNamespace, logic.NewClassifiedError(logic.InvalidNameReason, err))
+				continue
+			}
+
+			resolvedVariables[logic.TargetNameVariable] = targetName
+		}
+
+		target := targetNamespace + "/" + targetName
+		if claimedBy, ok := claimedTargets[target]; ok {
+			err = fmt.Errorf("target %s is already rendered by iteration: %s", target, claimedBy)
+			logger.Error(err, fmt.Sprintf("Conflicting destination for iteration: %s", iterationName))
+
+			fail(iterationName, targetNamespace, logic.NewClassifiedError(logic.TargetConflictReason, err))
+			continue
+		}
+		claimedTargets[target] = iterationName
+
+		if err = logic.ValidateVariables(declared, resolvedVariables); err != nil {
+			logger.Error(err, fmt.Sprintf("Invalid variables for iteration: %s", iterationName))
+
+			fail(iterationName, targetNamespace, logic.NewClassifiedError(logic.InvalidVariablesReason, err))
+			continue
+		}
+
+		plans = append(plans, iterationPlan{
+			name:            iterationName,
+			targetName:      targetName,
+			targetNamespace: targetNamespace,
+			variables:       resolvedVariables,
+			nameMaxLength:   cr.Spec.Destination.NameMaxLength(iteration),
+		})
+	}
+
+	return plans, failures
+}
+
+// reconcileIterations renders and applies the planned iterations with a bounded number of workers, following the
+// failure policy of the Konfiguration. Results are returned in the order of the plans, so the outcome does not depend
+// on the order the iterations finish in. planningFailed tells whether any iteration failed before being planned.
+func (r *KonfigurationReconciler) reconcileIterations(ctx context.Context, env *iterationEnvironment, plans []iterationPlan, planningFailed bool) []iterationResult {
+	logger := log.FromContext(ctx)
+	policy := env.cr.Spec.Reconciliation.FailurePolicy
+
+	switch policy {
+	case konfigurev1alpha1.FailurePolicyFailFast:
+		if planningFailed {
+			return r.abortIterations(ctx, env, plans, nil, "another iteration failed and the failure policy is FailFast")
+		}
+
+		return r.runIterations(ctx, env, len(plans), true, func(i int) iterationResult {
+			return r.reconcileIteration(ctx, env, plans[i])
+		})
+	case konfigurev1alpha1.FailurePolicyAtomic:
+		results := r.runIterations(ctx, env, len(plans), false, func(i int) iterationResult {
+			return r.prepareIteration(ctx, env, plans[i])
+		})
+
+		if planningFailed || slices.ContainsFunc(results, func(result iterationResult) bool { return result.failure != nil }) {
+			return r.abortIterations(ctx, env, plans, results, "another iteration failed to render or to pass the pre-flight checks and the failure policy is Atomic")
+		}
+
+		logger.Info(fmt.Sprintf("All %d iterations rendered and passed the pre-flight checks, applying them", len(plans)))
+
+		return r.runIterations(ctx, env, len(plans), false, func(i int) iterationResult {
+			return r.applyIteration(ctx, env, plans[i], results[i])
+		})
+	default:
+		return r.runIterations(ctx, env, len(plans), false, func(i int) iterationResult {
+			return r.reconcileIteration(ctx, env, plans[i])
+		})
+	}
+}
+
+// runIterations runs the given function for count iterations with a bounded number of workers. Once the context is
+// done, iterations that did not complete are reported as timed out without waiting for them, as rendering cannot be
+// interrupted. Their workers are tracked by the environment until they exit. With failFast, no further iterations are
+// started once one failed, and those are reported as aborted.
+func (r *KonfigurationReconciler) runIterations(ctx context.Context, env *iterationEnvironment, count int, failFast bool, run func(i int) iterationResult) []iterationResult {
+	results := make([]iterationResult, count)
+	completed := make([]bool, count)
+
+	workers := r.Options.IterationWorkers
+	if workers <= 0 {
+		workers = DefaultIterationWorkers
+	}
+
+	type indexedResult struct {
+		index  int
+		result iterationResult
+	}
+
+	queue := make(chan int)
+	// Buffered, so workers still running after a timeout never block on it.
+	done := make(chan indexedResult, count)
+
+	var failed atomic.Bool
+	var wg sync.WaitGroup
+	for range min(workers, count) {
+		wg.Go(func() {
+			for i := range queue {
+				if failFast && failed.Load() {
+					continue
+				}
+
+				result := run(i)
+				if result.failure != nil {
+					failed.Store(true)
+				}
+
+				done <- indexedResult{index: i, result: result}
+			}
+		})
+	}
+
+	finished := make(chan struct{})
+	env.workers.Go(func() {
+		wg.Wait()
+		close(finished)
+	})
+
+	go func() {
+		defer close(queue)
+
+		for i := range count {
+			select {
+			case queue <- i:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	select {
+	case <-finished:
+	case <-ctx.Done():
+	}
+
+	// Take the results that completed, on timeout the remaining ones are abandoned.
+	for drained := false; !drained; {
+		select {
+		case completion := <-done:
+			results[completion.index] = completion.result
+			completed[completion.index] = true
+		default:
+			drained = true
+		}
+	}
+
+	var aborted int
+	for i := range count {
+		if completed[i] {
+			continue
+		}
+
+		if ctx.Err() != nil {
+			results[i] = iterationResult{
+				failure: logic.NewClassifiedError(logic.TimedOutReason, fmt.Errorf("reconciliation timed out before the iteration completed: %w", ctx.Err())),
+			}
+			continue
+		}
+
+		results[i] = iterationResult{
+			failure: logic.NewClassifiedError(logic.AbortedReason, errors.New("iteration not reconciled as another iteration failed and the failure policy is FailFast")),
+		}
+		aborted++
+	}
+
+	if aborted > 0 {
+		r.Recorder.Eventf(env.cr, v1.EventTypeWarning, logic.AbortedReason, "Aborted %d iterations as another iteration failed and the failure policy is %s", aborted, env.cr.Spec.Reconciliation.FailurePolicy)
+	}
+
+	return results
+}
+
+// releaseAfterWorkers calls release once the last iteration worker exited, so resources they read are not released
+// under them. Workers only outlive a done context, otherwise release is called right away.
+func releaseAfterWorkers(ctx context.Context, workers *sync.WaitGroup, release func()) {
+	if ctx.Err() == nil {
+		release()
+		return
+	}
+
+	go func() {
+		workers.Wait()
+		release()
+	}()
+}
+
+// abortIterations reports the iterations that would have been applied as aborted for the given cause. Iterations that
+// failed or did not change keep their results.
+func (r *KonfigurationReconciler) abortIterations(ctx context.Context, env *iterationEnvironment, plans []iterationPlan, results []iterationResult, cause string) []iterationResult {
+	logger := log.FromContext(ctx)
+
+	if results == nil {
+		results = make([]iterationResult, len(plans))
+	}
+
+	var aborted int
+	for i := range results {
+		if results[i].failure != nil || results[i].status != nil {
+			continue
+		}
+
+		results[i].failure = logic.NewClassifiedError(logic.AbortedReason, fmt.Errorf("iteration not applied as %s", cause))
+		results[i].prepared = nil
+		aborted++
+	}
+
+	if aborted > 0 {
+		logger.Info(fmt.Sprintf("Aborted %d iterations as %s", aborted, cause))
+		r.Recorder.Eventf(env.cr, v1.EventTypeWarning, logic.AbortedReason, "Aborted %d iterations as %s", aborted, cause)
+	}
+
+	return results
+}
+
+// reconcileIteration renders and applies a single iteration. It is called concurrently for distinct iterations,
+// so it must only write to its own result.
+func (r *KonfigurationReconciler) reconcileIteration(ctx context.Context, env *iterationEnvironment, plan iterationPlan) iterationResult {
+	result := r.prepareIteration(ctx, env, plan)
+	if result.prepared == nil {
+		return result
+	}
+
+	return r.applyIteration(ctx, env, plan, result)
+}
+
+// prepareIteration renders a single iteration and runs the pre-flight checks of its manifests. Iterations whose
+// inputs and manifests did not change are not rendered and come back with their status instead.
+func (r *KonfigurationReconciler) prepareIteration(ctx context.Context, env *iterationEnvironment, plan iterationPlan) (result iterationResult) {
+	logger := log.FromContext(ctx)
+	cr := env.cr
+	output := cr.Spec.Destination.Output
+	iterationName := plan.name
+
+	fingerprint := logic.IterationFingerprint(env.revision, env.schemaDigest, cr.Generation, plan.variables)
+	if status, unchanged := r.unchangedIteration(ctx, env, iterationName, fingerprint); unchanged {
+		logger.Info(fmt.Sprintf("Skipping render of iteration: %s as its inputs and manifests did not change", iterationName))
+
+		RecordRendering(cr, iterationName, plan.targetNamespace, true)
+		result.status = status
+		return result
+	}
+
+	rawVariables := logic.FormatRawVariables(plan.variables)
+
+	renderStart := time.Now()
+	configmap, secret, err := env.service.Render(konfigureService.RenderInput{
+		Dir:              env.sourceDir,
+		Schema:           env.schemaFilePath,
+		Variables:        rawVariables,
+		Name:             plan.targetName,
+		Namespace:        plan.targetNamespace,
+		ConfigMapDataKey: env.configMapDataKey,
+		SecretDataKey:    env.secretDataKey,
+		// The labels are shared by the rendered config map and secret, so each iteration gets its own copy.
+		ExtraLabels: maps.Clone(env.ownershipLabels),
+	})
+	result.renderDuration = &metav1.Duration{Duration: time.Since(renderStart)}
+	RecordRenderDuration(cr, result.renderDuration.Duration)
+
+	if err != nil {
+		logger.Error(err, fmt.Sprintf("Failed to render iteration: %s with variables: %s", iterationName, strings.Join(rawVariables, ",")))
+
+		result.failure = logic.ClassifyError(err, logic.RenderFailedReason)
+
+		RecordRendering(cr, iterationName, plan.targetNamespace, false)
+		r.Recorder.Eventf(cr, v1.EventTypeWarning, logic.RenderFailedReason, "Failed to render iteration %s: %s", iterationName, err.Error())
+		return result
+	}
+
+	RecordRendering(cr, iterationName, plan.targetNamespace, true)
+
+	// The pre-flight checks count towards applying.
+	applyStart := time.Now()
+	defer func() {
+		result.applyDuration = &metav1.Duration{Duration: time.Since(applyStart)}
+		if result.failure != nil {
+			RecordApplyDuration(cr, result.applyDuration.Duration)
+		}
+	}()
+
+	logger.Info(fmt.Sprintf("Successfully rendered iteration: %s", iterationName))
+
+	prepared := &preparedIteration{fingerprint: fingerprint}
+	if output.RendersConfigMap() {
+		prepared.configMapChecksum = logic.ConfigMapContentHash(configmap.Data)
+		logic.StampChecksum(&configmap.ObjectMeta, prepared.configMapChecksum)
+	}
+	if output.RendersSecret() {
+		prepared.secretChecksum = logic.ContentHash(secret.Data)
+		logic.StampChecksum(&secret.ObjectMeta, prepared.secretChecksum)
+	}
+
+	if output.Immutable != nil {
+		configmap = logic.ImmutableConfigMap(configmap, plan.nameMaxLength)
+		secret = logic.ImmutableSecret(secret, plan.nameMaxLength)
+	}
+
+	prepared.configmap = configmap
+	prepared.secret = secret
+
+	// Pre-flight check config map apply
+	if output.RendersConfigMap() {
+		if err = r.canApplyConfigMap(ctx, configmap); err != nil {
+			r.recordPreflightFailure(cr, iterationName, err)
+
+			result.failure = logic.ClassifyError(err, logic.ApplyFailedReason)
+		}
+	}
+
+	// Pre-flight check secret apply. Present both errors to avoid the need to fix in multiple turns.
+	if output.RendersSecret() {
+		if err = r.canApplySecret(ctx, secret); err != nil {
+			r.recordPreflightFailure(cr, iterationName, err)
+
+			if result.failure != nil {
+				err = fmt.Errorf("%w %w", result.failure, err)
+			}
+
+			result.failure = logic.ClassifyError(err, logic.ApplyFailedReason)
+		}
+	}
+
+	if result.failure == nil {
+		result.prepared = prepared
+	}
+
+	return result
+}
+
+// applyIteration applies the manifests of a prepared iteration, prunes their previous immutable generations and
+// triggers the rollouts of their consumers.
+func (r *KonfigurationReconciler) applyIteration(ctx context.Context, env *iterationEnvironment, plan iterationPlan, prepared iterationResult) (result iterationResult) {
+	logger := log.FromContext(ctx)
+	cr := env.cr
+	output := cr.Spec.Destination.Output
+	iterationName := plan.name
+
+	result = prepared
+	// Iterations that did not change or failed to be prepared have nothing to apply.
+	if result.prepared == nil {
+		return result
+	}
+
+	configmap, secret := result.prepared.configmap, result.prepared.secret
+	configMapChecksum, secretChecksum := result.prepared.configMapChecksum, result.prepared.secretChecksum
+	fingerprint := result.prepared.fingerprint
+	result.prepared = nil
+
+	applyStart := time.Now()
+	defer func() {
+		result.applyDuration = &metav1.Duration{Duration: result.applyDuration.Duration + time.Since(applyStart)}
+		RecordApplyDuration(cr, result.applyDuration.Duration)
+	}()
+
+	var err error
+
+	// Changed tells whether any applied data changed, disabled tells whether any manifest was left untouched.
+	changed, disabled := false, false
+	var outcome konfigurev1alpha1.ApplyOutcome
+
+	if output.RendersConfigMap() {
+		shouldReconcile, applied, err := r.applyConfigMap(ctx, configmap)
+		changed = changed || len(applied.ChangedKeys) > 0
+		outcome = outcome.Merge(applied.Outcome)
+		if !shouldReconcile {
+			disabled = true
+
+			logger.Info(fmt.Sprintf("Skipping apply for configmap %s/%s as it is disabled for reconciliation", configmap.Namespace, configmap.Name))
+
+			result.disabled = append(result.disabled, konfigurev1alpha1.DisabledIteration{
+				Name: iterationName,
+				Kind: "ConfigMap",
+				Target: konfigurev1alpha1.DisabledIterationTarget{
+					Name:      configmap.Name,
+					Namespace: configmap.Namespace,
+				},
+			})
+
+			r.Recorder.Eventf(cr, v1.EventTypeNormal, logic.ReconciliationDisabledReason, "Skipped applying ConfigMap %s/%s for iteration %s as it is disabled for reconciliation", configmap.Namespace, configmap.Name, iterationName)
+		}
+
+		if err != nil {
+			logger.Error(err, fmt.Sprintf("Failed to apply configmap %s/%s for app: %s", configmap.Namespace, configmap.Name, iterationName))
+
+			result.failure = logic.ClassifyError(err, logic.ApplyFailedReason)
+			r.Recorder.Eventf(cr, v1.EventTypeWarning, logic.ApplyFailedReason, "Failed to apply iteration %s: %s", iterationName, err.Error())
+			return result
+		}
+
+		if shouldReconcile {
+			r.recordApply(cr, iterationName, "ConfigMap", configmap.ObjectMeta, applied)
+		}
+	}
+
+	if output.RendersSecret() {
+		shouldReconcile, applied, err := r.applySecret(ctx, secret)
+		changed = changed || len(applied.ChangedKeys) > 0
+		outcome = outcome.Merge(applied.Outcome)
+		if !shouldReconcile {
+			disabled = true
+
+			logger.Info(fmt.Sprintf("Skipping apply for secret %s/%s as it is disabled for reconciliation", secret.Namespace, secret.Name))
+
+			result.disabled = append(result.disabled, konfigurev1alpha1.DisabledIteration{
+				Name: iterationName,
+				Kind: "Secret",
+				Target: konfigurev1alpha1.DisabledIterationTarget{
+					Name:      secret.Name,
+					Namespace: secret.Namespace,
+				},
+			})
+
+			r.Recorder.Eventf(cr, v1.EventTypeNormal, logic.ReconciliationDisabledReason, "Skipped applying Secret %s/%s for iteration %s as it is disabled for reconciliation", secret.Namespace, secret.Name, iterationName)
+		}
+
+		if err != nil {
+			logger.Error(err, fmt.Sprintf("Failed to apply secret %s/%s for app: %s", secret.Namespace, secret.Name, iterationName))
+
+			result.failure = logic.ClassifyError(err, logic.ApplyFailedReason)
+			r.Recorder.Eventf(cr, v1.EventTypeWarning, logic.ApplyFailedReason, "Failed to apply iteration %s: %s", iterationName, err.Error())
+			return result
+		}
+
+		if shouldReconcile {
+			r.recordApply(cr, iterationName, "Secret", secret.ObjectMeta, applied)
+		}
+	}
+
+	if output.Immutable != nil {
+		if err = r.pruneImmutableGenerations(ctx, output, configmap, secret, output.Immutable.GetRetain()); err != nil {
+			logger.Error(err, fmt.Sprintf("Failed to prune previous immutable generations for app: %s", iterationName))
+
+			result.failure = logic.ClassifyError(err, logic.ApplyFailedReason)
+			r.Recorder.Eventf(cr, v1.EventTypeWarning, logic.ApplyFailedReason, "Failed to apply iteration %s: %s", iterationName, err.Error())
+			return result
+		}
+	}
+
+	previous := env.previousStatuses[iterationName]
+
+	// Only propagate data that was applied as a whole, the previous checksum is kept until then.
+	checksum := previous.Checksum
+	if !disabled {
+		checksum = logic.IterationChecksum(configMapChecksum, secretChecksum)
+
+		if logic.ShouldTriggerRollout(previous.Checksum, checksum, changed) {
+			if err = r.triggerRollouts(ctx, cr.Spec.Destination.RolloutTriggers, iterationName, plan.targetNamespace, plan.variables, checksum); err != nil {
+				logger.Error(err, fmt.Sprintf("Failed to trigger rollouts for app: %s", iterationName))
+
+				result.failure = logic.ClassifyError(err, logic.ApplyFailedReason)
+				r.Recorder.Eventf(cr, v1.EventTypeWarning, logic.ApplyFailedReason, "Failed to apply iteration %s: %s", iterationName, err.Error())
+				return result
+			}
+		}
+	}
+
+	status := &konfigurev1alpha1.IterationStatus{
+		Name:        iterationName,
+		Result:      konfigurev1alpha1.IterationResultSucceeded,
+		Checksum:    checksum,
+		Outcome:     outcome,
+		Fingerprint: fingerprint,
+		// Disabled iterations did not apply anything, so they keep the revision of their last write.
+		LastAppliedRevision: env.revision,
+	}
+	if disabled {
+		status.Result = konfigurev1alpha1.IterationResultDisabled
+		status.LastAppliedRevision = previous.LastAppliedRevision
+	}
+	if output.RendersConfigMap() {
+		status.ConfigMap = &konfigurev1alpha1.TargetReference{Name: configmap.Name, Namespace: configmap.Namespace, Checksum: configMapChecksum}
+	}
+	if output.RendersSecret() && !logic.IsEmptySecretData(secret.Data) {
+		status.Secret = &konfigurev1alpha1.TargetReference{Name: secret.Name, Namespace: secret.Namespace, Checksum: secretChecksum}
+	}
+	result.status = status
+
+	logger.Info(fmt.Sprintf("Successfully reconciled rendered output for: %s with outcome: %s", iterationName, outcome))
+
+	return result
+}
+
+// unchangedIteration returns the previous status of the iteration when rendering it again would not change anything:
+// its fingerprint matches its last successful reconciliation and its manifests still hold the data rendered then.
+// Manifests changed in the meantime are rendered and applied again to repair the drift.
+func (r *KonfigurationReconciler) unchangedIteration(ctx context.Context, env *iterationEnvironment, iterationName, fingerprint string) (*konfigurev1alpha1.IterationStatus, bool) {
+	previous, ok := env.previousStatuses[iterationName]
+	if !ok || env.schemaDigest == "" || previous.Result != konfigurev1alpha1.IterationResultSucceeded || previous.Fingerprint != fingerprint {
+		return nil, false
+	}
+
+	output := env.cr.Spec.Destination.Output
+
+	if output.RendersConfigMap() {
+		if previous.ConfigMap == nil {
+			return nil, false
+		}
+
+		live := &v1.ConfigMap{}
+		if err := r.Get(ctx, client.ObjectKey{Name: previous.ConfigMap.Name, Namespace: previous.ConfigMap.Namespace}, live); err != nil {
+			return nil, false
+		}
+
+		if !logic.IsRenderedUnchanged(live.ObjectMeta, logic.ConfigMapContentHash(live.Data), previous.ConfigMap.Checksum, env.ownershipLabels) {
+			return nil, false
+		}
+	}
+
+	// Secrets without content are not created, so there is nothing to check then.
+	if output.RendersSecret() && previous.Secret != nil {
+		live := &v1.Secret{}
+		if err := r.Get(ctx, client.ObjectKey{Name: previous.Secret.Name, Namespace: previous.Secret.Namespace}, live); err != nil {
+			return nil, false
+		}
+
+		if !logic.IsRenderedUnchanged(live.ObjectMeta, logic.ContentHash(live.Data), previous.Secret.Checksum, env.ownershipLabels) {
+			return nil, false
+		}
+	}
+
+	status := previous
+	status.Outcome = konfigurev1alpha1.ApplyOutcomeUnchanged
+	status.RenderSkipped = true
+	status.LastError = nil
+	status.RenderDuration = nil
+	status.ApplyDuration = nil
+
+	return &status, true
+}
@@ -0,0 +1,99 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"k8s.io/client-go/tools/record"
+
+	konfigurev1alpha1 "github.com/giantswarm/konfigure-operator/api/v1alpha1"
+	"github.com/giantswarm/konfigure-operator/internal/controller/logic"
+)
+
+func TestRunIterations(t *testing.T) {
+	testCases := []struct {
+		name     string
+		failFast bool
+		timeout  time.Duration
+		// block makes the iteration outlast the timeout.
+		block    int
+		expected []string
+	}{
+		{
+			name:     "continue runs all iterations",
+			block:    -1,
+			expected: []string{"", logic.RenderFailedReason, "", ""},
+		},
+		{
+			name:     "fail fast stops starting iterations",
+			failFast: true,
+			block:    -1,
+			expected: []string{"", logic.RenderFailedReason, logic.AbortedReason, logic.AbortedReason},
+		},
+		{
+			name:     "timeout abandons running iterations",
+			timeout:  50 * time.Millisecond,
+			block:    2,
+			expected: []string{"", logic.RenderFailedReason, logic.TimedOutReason, logic.TimedOutReason},
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("case %d: %s", i, tc.name), func(t *testing.T) {
+			r := &KonfigurationReconciler{
+				Recorder: record.NewFakeRecorder(10),
+				Options:  KonfigurationReconcilerOptions{IterationWorkers: 1},
+			}
+			var workers sync.WaitGroup
+			env := &iterationEnvironment{cr: &konfigurev1alpha1.Konfiguration{}, workers: &workers}
+
+			ctx := context.Background()
+			if tc.timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, tc.timeout)
+				defer cancel()
+			}
+
+			var exited atomic.Int32
+			results := r.runIterations(ctx, env, len(tc.expected), tc.failFast, func(i int) iterationResult {
+				defer exited.Add(1)
+
+				if i == tc.block {
+					time.Sleep(4 * tc.timeout)
+				}
+
+				if i == 1 {
+					return iterationResult{failure: logic.NewClassifiedError(logic.RenderFailedReason, errors.New("failed"))}
+				}
+
+				return iterationResult{status: &konfigurev1alpha1.IterationStatus{}}
+			})
+
+			var reasons []string
+			for _, result := range results {
+				reason := ""
+				if result.failure != nil {
+					reason = result.failure.Reason
+				}
+
+				reasons = append(reasons, reason)
+			}
+
+			if fmt.Sprint(reasons) != fmt.Sprint(tc.expected) {
+				t.Fatalf("expected reasons %q, got %q", tc.expected, reasons)
+			}
+
+			// Resources read by the iterations must outlive the workers abandoned on timeout.
+			before := exited.Load()
+			workers.Wait()
+			if tc.timeout > 0 && exited.Load() == before {
+				t.Fatalf("expected workers to be waited for until the abandoned iteration exited")
+			}
+		})
+	}
+}
@@ -18,13 +18,14 @@ package controller
 
 import (
 	"bytes"
+	"cmp"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"maps"
 	"net/http"
 	"os"
-	"path"
 	"slices"
 	"strings"
 	"sync"
@@ -35,21 +36,29 @@ import (
 
 	v1 "k8s.io/api/core/v1"
 
-	"github.com/giantswarm/konfigure-operator/internal/konfigure"
-
 	konfigureModel "github.com/giantswarm/konfigure/v2/pkg/model"
+	konfigureRenderer "github.com/giantswarm/konfigure/v2/pkg/renderer"
 	konfigureService "github.com/giantswarm/konfigure/v2/pkg/service"
 
 	apiMachineryErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 
+	metav1ac "k8s.io/client-go/applyconfigurations/meta/v1"
+	"k8s.io/client-go/util/csaupgrade"
+	"k8s.io/utils/ptr"
+
 	"github.com/giantswarm/konfigure-operator/internal/controller/logic"
 
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
@@ -66,13 +75,44 @@ type KonfigurationReconcilerOptions struct {
 	// SchemaFetchIdleConnTimeout is the transport idle connection timeout for the same HTTP client.
 	// Zero means no limit.
 	SchemaFetchIdleConnTimeout time.Duration
+
+	// IterationWorkers is the number of iterations of a single Konfiguration rendered and applied in parallel.
+	// Zero or less falls back to DefaultIterationWorkers.
+	IterationWorkers int
+
+	// ReconcileTimeout is the maximum duration of a single reconciliation of Konfigurations not setting their own.
+	// Zero means no timeout.
+	ReconcileTimeout time.Duration
+
+	// IntervalJitterPercentage is the percentage by which intervals are randomly shifted in either direction.
+	// Zero means no jitter.
+	IntervalJitterPercentage int
+
+	// MaxConcurrentReconciles is the number of different Konfigurations reconciled in parallel.
+	MaxConcurrentReconciles int
+
+	// ArtifactCacheMaxSize is the size in bytes above which old source revisions are evicted from the artifact cache.
+	// Zero falls back to DefaultArtifactCacheMaxSize.
+	ArtifactCacheMaxSize int64
+
+	// ChangeFreeze holds back new revisions of all Konfigurations, they are rendered but not applied.
+	ChangeFreeze bool
 }
 
 // KonfigurationReconciler reconciles a Konfiguration object
 type KonfigurationReconciler struct {
 	client.Client
-	Scheme  *runtime.Scheme
-	Options KonfigurationReconcilerOptions
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+	Options  KonfigurationReconcilerOptions
+
+	// Workspaces isolates concurrent reconciliations, defaults to the directories of the operator image.
+	Workspaces *WorkspaceManager
+	// Artifacts shares the downloaded source artifacts between Konfigurations, defaults to the cache volume.
+	Artifacts *ArtifactCache
+	// APIReader reads objects not held by the cache of the client, like the SOPS keys, defaults to the one of the
+	// manager.
+	APIReader client.Reader
 
 	schemaHTTPClientOnce sync.Once
 	schemaHTTPClient     *http.Client
@@ -92,6 +132,7 @@ func (r *KonfigurationReconciler) getSchemaHTTPClient() *http.Client {
 // +kubebuilder:rbac:groups=konfigure.giantswarm.io,resources=konfigurations,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=konfigure.giantswarm.io,resources=konfigurations/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=konfigure.giantswarm.io,resources=konfigurations/finalizers,verbs=update
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -162,27 +203,83 @@ func (r *KonfigurationReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 		return ctrl.Result{}, nil
 	}
 
+	// Report a new generation being picked up, so kstatus compatible tools do not consider the outdated
+	// conditions as the outcome of the new generation.
+	if cr.Status.ObservedGeneration != cr.Generation {
+		message := fmt.Sprintf("Reconciling generation: %d", cr.Generation)
+
+		logic.SetCondition(&cr.Status.Conditions, logic.ReconcilingCondition, metav1.ConditionTrue, logic.ProgressingReason, message, cr.Generation)
+		logic.SetCondition(&cr.Status.Conditions, logic.ReadyCondition, metav1.ConditionUnknown, logic.ProgressingReason, message, cr.Generation)
+
+		if err := r.Status().Update(ctx, cr); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	run := konfigurev1alpha1.ReconciliationRun{StartedAt: metav1.NewTime(reconcileStart)}
+
+	// Bound the run, the status is still updated with the reconcile context, so a timed out run is recorded.
+	timeout := cr.Spec.Reconciliation.GetTimeout(r.Options.ReconcileTimeout)
+	runCtx := ctx
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		runCtx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	// Iteration workers outlive a timed out run, so the workspace, the source artifact and the SOPS keys they read are
+	// only released once they exited.
+	var workers sync.WaitGroup
+
+	// Acquire workspace
+	workspace, err := r.Workspaces.Acquire(cr)
+	if err != nil {
+		failure := logic.ClassifyError(err, logic.SetupFailedReason)
+
+		if updateStatusErr := r.updateStatusOnSetupFailure(ctx, cr, run, "workspace", failure, false); updateStatusErr != nil {
+			logger.Error(updateStatusErr, "Failed to update status on setup failure")
+		}
+
+		return ctrl.Result{RequeueAfter: r.requeueAfter(cr)}, nil
+	}
+
+	defer releaseAfterWorkers(runCtx, &workers, func() {
+		if err := workspace.Release(); err != nil {
+			logger.Error(err, fmt.Sprintf("Failed to remove workspace: %s", workspace.Dir))
+		}
+	})
+
 	// Initialize SOPS Environment
-	sops, err := InitializeSopsEnv(ctx, "/sopsenv/kfg")
+	sops, err := workspace.SetupSops(runCtx, r.APIReader)
 	if err != nil {
-		if updateStatusErr := r.updateStatusOnSetupFailure(ctx, cr, err); updateStatusErr != nil {
+		failure := logic.ClassifyError(err, logic.DecryptionFailedReason)
+		logic.SetCondition(&cr.Status.Conditions, logic.DecryptionReadyCondition, metav1.ConditionFalse, failure.Reason, err.Error(), cr.Generation)
+
+		if updateStatusErr := r.updateStatusOnSetupFailure(ctx, cr, run, "SOPS environment", failure, false); updateStatusErr != nil {
 			logger.Error(updateStatusErr, "Failed to update status on setup failure")
 		}
 
-		return ctrl.Result{RequeueAfter: cr.Spec.Reconciliation.RetryInterval.Duration}, err
+		return ctrl.Result{RequeueAfter: r.requeueAfter(cr)}, nil
 	}
 	logger.Info(fmt.Sprintf("SOPS environment successfully set up at: %s", sops.GetKeysDir()))
+	logic.SetCondition(&cr.Status.Conditions, logic.DecryptionReadyCondition, metav1.ConditionTrue, logic.SucceededReason, "SOPS environment set up", cr.Generation)
 
-	// Initialize Flux Updater
-	fluxUpdater, err := InitializeFluxUpdater("/tmp/konfigure-cache/kfg", cr.Spec.Sources.Flux)
+	// Acquire source artifact
+	artifact, err := r.acquireSourceArtifact(runCtx, cr.Spec.Sources.Flux)
 	if err != nil {
-		if updateStatusErr := r.updateStatusOnSetupFailure(ctx, cr, err); updateStatusErr != nil {
+		failure := logic.ClassifyError(err, logic.SourceUnavailableReason)
+		logic.SetCondition(&cr.Status.Conditions, logic.SourceReadyCondition, metav1.ConditionFalse, failure.Reason, err.Error(), cr.Generation)
+
+		if updateStatusErr := r.updateStatusOnSetupFailure(ctx, cr, run, "Flux source", failure, false); updateStatusErr != nil {
 			logger.Error(updateStatusErr, "Failed to update status on setup failure")
 		}
 
-		return ctrl.Result{RequeueAfter: cr.Spec.Reconciliation.RetryInterval.Duration}, err
+		return ctrl.Result{RequeueAfter: r.requeueAfter(cr)}, nil
 	}
-	logger.Info("Konfigure cache successfully updated!")
+	defer releaseAfterWorkers(runCtx, &workers, artifact.Release)
+
+	logger.Info(fmt.Sprintf("Source artifact of revision: %s available at: %s", artifact.Revision, artifact.Dir))
+	logic.SetCondition(&cr.Status.Conditions, logic.SourceReadyCondition, metav1.ConditionTrue, logic.SucceededReason, "Source artifact fetched", cr.Generation)
 
 	// Initialize Dynamic Service
 	var dynamicServiceLogger logr.Logger
@@ -197,227 +294,443 @@ func (r *KonfigurationReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 	})
 
 	// Fetch konfiguration schema
-	schemaFilePath, err := r.fetchKonfigurationSchema(ctx, cr.Spec.Targets.Schema)
+	schemaFilePath, err := r.fetchKonfigurationSchema(runCtx, workspace.SchemaDir, cr.Spec.Targets.Schema)
+	if err != nil {
+		failure := logic.ClassifyError(err, logic.SchemaUnavailableReason)
+		logic.SetCondition(&cr.Status.Conditions, logic.SchemaReadyCondition, metav1.ConditionFalse, failure.Reason, err.Error(), cr.Generation)
 
-	defer func(name string) {
-		if schemaFilePath == "" {
-			return
+		if updateStatusErr := r.updateStatusOnSetupFailure(ctx, cr, run, "konfiguration schema", failure, false); updateStatusErr != nil {
+			logger.Error(updateStatusErr, "Failed to update status on setup failure")
 		}
 
-		err := os.Remove(name)
-		if err != nil {
-			logger.Error(err, fmt.Sprintf("Failed to remove temporary schema file: %s", schemaFilePath))
-		}
-	}(schemaFilePath)
+		return ctrl.Result{RequeueAfter: r.requeueAfter(cr)}, nil
+	}
+	logger.Info(fmt.Sprintf("Konfiguration schema file path: %s", schemaFilePath))
 
+	if schemaContent, readErr := os.ReadFile(schemaFilePath); readErr == nil {
+		run.SchemaDigest = logic.SchemaDigest(schemaContent)
+	} else {
+		logger.Error(readErr, fmt.Sprintf("Failed to read schema file for digest: %s", schemaFilePath))
+	}
+
+	schema, err := konfigureRenderer.LoadSchema(schemaFilePath)
 	if err != nil {
-		if updateStatusErr := r.updateStatusOnSetupFailure(ctx, cr, err); updateStatusErr != nil {
+		// Retrying does not help until the schema is fixed.
+		failure := logic.ClassifyError(err, logic.InvalidSchemaReason)
+		logic.SetCondition(&cr.Status.Conditions, logic.SchemaReadyCondition, metav1.ConditionFalse, failure.Reason, err.Error(), cr.Generation)
+		logic.SetCondition(&cr.Status.Conditions, logic.StalledCondition, metav1.ConditionTrue, failure.Reason, err.Error(), cr.Generation)
+
+		if updateStatusErr := r.updateStatusOnSetupFailure(ctx, cr, run, "konfiguration schema", failure, true); updateStatusErr != nil {
 			logger.Error(updateStatusErr, "Failed to update status on setup failure")
 		}
 
-		return ctrl.Result{RequeueAfter: cr.Spec.Reconciliation.RetryInterval.Duration}, err
+		return ctrl.Result{RequeueAfter: r.requeueAfter(cr)}, nil
 	}
-	logger.Info(fmt.Sprintf("Konfiguration schema file path: %s", schemaFilePath))
+	logic.SetCondition(&cr.Status.Conditions, logic.SchemaReadyCondition, metav1.ConditionTrue, logic.SucceededReason, "Schema loaded", cr.Generation)
 
-	revision, err := konfigure.GetLastArchiveSHA(fluxUpdater.CacheDir)
-	if err != nil {
-		logger.Error(err, fmt.Sprintf("Failed to get last archive SHA from: %s", fluxUpdater.CacheDir))
-		revision = "unknown"
-	}
+	revision := artifact.Revision
+	run.Revision = revision
 
 	ownershipLabels := logic.GenerateOwnershipLabels(cr.GroupVersionKind(), cr.ObjectMeta, revision)
 
+	output := cr.Spec.Destination.Output
+	configMapDataKey := cmp.Or(output.ConfigMapDataKey, konfigureModel.DefaultConfigMapDataKey)
+	secretDataKey := cmp.Or(output.SecretDataKey, konfigureModel.DefaultSecretDataKey)
+
 	// Render targets
 	iterationNames := slices.Collect(maps.Keys(cr.Spec.Targets.Iterations))
 	slices.Sort(iterationNames)
 
-	failures := make(map[string]string)
-	var disabledIterations []konfigurev1alpha1.DisabledIteration
-	for _, iterationName := range iterationNames {
-		iteration := cr.Spec.Targets.Iterations[iterationName]
+	previousIterationStatuses := make(map[string]konfigurev1alpha1.IterationStatus)
+	previousReport, err := r.getReport(runCtx, cr)
+	if err != nil {
+		// Failed iterations lose their previous references and rollout triggers only fire on changed data then.
+		logger.Error(err, fmt.Sprintf("Failed to read the previous report of: %s/%s", cr.GetNamespace(), cr.GetName()))
+	}
+	if previousReport != nil {
+		for _, previous := range previousReport.Iterations {
+			previousIterationStatuses[previous.Name] = previous
+		}
+	}
 
-		variables := make(map[string]string)
+	env := &iterationEnvironment{
+		cr:               cr,
+		service:          service,
+		sourceDir:        artifact.Dir,
+		schemaFilePath:   schemaFilePath,
+		schemaDigest:     run.SchemaDigest,
+		revision:         revision,
+		ownershipLabels:  ownershipLabels,
+		configMapDataKey: configMapDataKey,
+		secretDataKey:    secretDataKey,
+		previousStatuses: previousIterationStatuses,
+		workers:          &workers,
+	}
+
+	// Stage new revisions across the waves of the rollout, iterations of the waves not started yet are left at their
+	// previous revision.
+	waves, err := logic.RolloutWaves(cr.Spec.Reconciliation.Rollout, cr.Spec.Targets.Iterations)
+	if err != nil {
+		// Retrying does not help until the rollout is fixed.
+		failure := logic.NewClassifiedError(logic.InvalidRolloutReason, err)
+		logic.SetCondition(&cr.Status.Conditions, logic.StalledCondition, metav1.ConditionTrue, failure.Reason, err.Error(), cr.Generation)
 
-		for _, defaultVariable := range cr.Spec.Targets.Defaults.Variables {
-			variables[defaultVariable.Name] = defaultVariable.Value
+		if updateStatusErr := r.updateStatusOnSetupFailure(ctx, cr, run, "rollout", failure, true); updateStatusErr != nil {
+			logger.Error(updateStatusErr, "Failed to update status on setup failure")
 		}
 
-		for _, valueOverride := range iteration.Variables {
-			variables[valueOverride.Name] = valueOverride.Value
+		return ctrl.Result{RequeueAfter: r.requeueAfter(cr)}, nil
+	}
+
+	windows, err := logic.ParseWindows(cr.Spec.Reconciliation.Windows)
+	if err != nil {
+		// Retrying does not help until the windows are fixed.
+		failure := logic.NewClassifiedError(logic.InvalidWindowsReason, err)
+		logic.SetCondition(&cr.Status.Conditions, logic.StalledCondition, metav1.ConditionTrue, failure.Reason, err.Error(), cr.Generation)
+
+		if updateStatusErr := r.updateStatusOnSetupFailure(ctx, cr, run, "maintenance windows", failure, true); updateStatusErr != nil {
+			logger.Error(updateStatusErr, "Failed to update status on setup failure")
 		}
 
-		var rawVariables []string
-		for k, v := range variables {
-			rawVariables = append(rawVariables, fmt.Sprintf("%s=%s", k, v))
+		return ctrl.Result{RequeueAfter: r.requeueAfter(cr)}, nil
+	}
+
+	// The change freeze and the maintenance windows hold back new revisions. Rollouts started already keep applying
+	// their current waves, but do not start the next ones.
+	var holdReason string
+	var nextWindowAt time.Time
+	if now := time.Now(); revision != cr.Status.LastAppliedRevision {
+		if r.Options.ChangeFreeze {
+			holdReason = logic.ChangeFreezeReason
+		} else if nextWindowAt = logic.NextApplyTime(windows, now); !nextWindowAt.Equal(now) {
+			holdReason = logic.OutsideMaintenanceWindowReason
 		}
+	}
 
-		configmap, secret, err := service.Render(konfigureService.RenderInput{
-			Dir:              path.Join(fluxUpdater.CacheDir, "latest"),
-			Schema:           schemaFilePath,
-			Variables:        rawVariables,
-			Name:             cr.Spec.Destination.Naming.Render(iterationName),
-			Namespace:        cr.Spec.Destination.Namespace,
-			ConfigMapDataKey: konfigureModel.DefaultConfigMapDataKey,
-			SecretDataKey:    konfigureModel.DefaultSecretDataKey,
-			ExtraLabels:      ownershipLabels,
-		})
-		if err != nil {
-			logger.Error(err, fmt.Sprintf("Failed to render iteration: %s with variables: %s", iterationName, strings.Join(rawVariables, ",")))
+	rolloutStarted := cr.Status.Rollout != nil && cr.Status.Rollout.Revision == revision
 
-			failures[iterationName] = err.Error()
+	// Revisions awaiting approval or held back are only rendered, their rollout starts once they may be applied.
+	awaitingApproval := logic.AwaitsApproval(cr, revision)
+	held := holdReason != "" && !rolloutStarted
 
-			RecordRendering(cr, iterationName, false)
-			continue
-		} else {
-			RecordRendering(cr, iterationName, true)
+	pendingReason := holdReason
+	if awaitingApproval {
+		pendingReason = logic.ApprovalPendingReason
+	}
+
+	rollout := cr.Status.Rollout
+	var pending map[string]bool
+	if awaitingApproval || held {
+		logger.Info(fmt.Sprintf("Revision: %s is held back with reason: %s, rendering it without applying", revision, pendingReason))
+	} else {
+		rollout = logic.StartRollout(cr.Status.Rollout, revision, cr.Status.LastAppliedRevision, waves)
+		pending = logic.PendingIterations(rollout, waves)
+		if len(pending) > 0 {
+			logger.Info(fmt.Sprintf("Rollout of revision: %s is at wave: %s, holding back %d iterations", revision, rollout.Wave, len(pending)))
 		}
+	}
 
-		logger.Info(fmt.Sprintf("Successfully rendered iteration: %s", iterationName))
+	plans, failures := r.planIterations(runCtx, env, schema.Variables, iterationNames)
 
-		// Pre-flight check config map apply
-		if err = r.canApplyConfigMap(ctx, configmap); err != nil {
-			failures[iterationName] = err.Error()
-		}
+	plansByName := make(map[string]iterationPlan, len(plans))
+	var activePlans []iterationPlan
+	for _, plan := range plans {
+		plansByName[plan.name] = plan
 
-		// Pre-flight check secret apply. Present both errors to avoid the need to fix in multiple turns.
-		if err = r.canApplySecret(ctx, secret); err != nil {
-			if failures[iterationName] != "" {
-				failures[iterationName] = failures[iterationName] + " " + err.Error()
-			} else {
-				failures[iterationName] = err.Error()
-			}
+		if !pending[plan.name] {
+			activePlans = append(activePlans, plan)
 		}
+	}
 
-		if failures[iterationName] != "" {
-			continue
+	// Other reconciliations must not update the keys while this one decrypts the source with them.
+	releaseSops, err := workspace.ReadSops(runCtx)
+	if err != nil {
+		failure := logic.ClassifyError(err, logic.DecryptionFailedReason)
+		logic.SetCondition(&cr.Status.Conditions, logic.DecryptionReadyCondition, metav1.ConditionFalse, failure.Reason, err.Error(), cr.Generation)
+
+		if updateStatusErr := r.updateStatusOnSetupFailure(ctx, cr, run, "SOPS environment", failure, false); updateStatusErr != nil {
+			logger.Error(updateStatusErr, "Failed to update status on setup failure")
 		}
 
-		shouldReconcile, err := r.applyConfigMap(ctx, configmap)
-		if !shouldReconcile {
-			logger.Info(fmt.Sprintf("Skipping apply for configmap %s/%s as it is disabled for reconciliation", configmap.Namespace, configmap.Name))
+		return ctrl.Result{RequeueAfter: r.requeueAfter(cr)}, nil
+	}
 
-			disabledIterations = append(disabledIterations, konfigurev1alpha1.DisabledIteration{
-				Name: iterationName,
-				Kind: "ConfigMap",
-				Target: konfigurev1alpha1.DisabledIterationTarget{
-					Name:      secret.Name,
-					Namespace: secret.Namespace,
-				},
-			})
-		}
+	var results []iterationResult
+	if awaitingApproval || held {
+		results = r.previewIterations(runCtx, env, activePlans)
+	} else {
+		results = r.reconcileIterations(runCtx, env, activePlans, len(failures) > 0)
+	}
+	releaseAfterWorkers(runCtx, &workers, releaseSops)
 
-		if err != nil {
-			logger.Error(err, fmt.Sprintf("Failed to apply configmap %s/%s for app: %s", configmap.Namespace, configmap.Name, iterationName))
+	resultsByName := make(map[string]iterationResult, len(results))
+	var disabledIterations []konfigurev1alpha1.DisabledIteration
+	for i, result := range results {
+		resultsByName[activePlans[i].name] = result
+		disabledIterations = append(disabledIterations, result.disabled...)
 
-			failures[iterationName] = err.Error()
-			continue
+		if result.failure != nil {
+			failures[activePlans[i].name] = result.failure
 		}
+	}
 
-		shouldReconcile, err = r.applySecret(ctx, secret)
-		if !shouldReconcile {
-			logger.Info(fmt.Sprintf("Skipping apply for secret %s/%s as it is disabled for reconciliation", configmap.Namespace, configmap.Name))
+	logger.Info(fmt.Sprintf("Failures: %s", failures))
 
-			disabledIterations = append(disabledIterations, konfigurev1alpha1.DisabledIteration{
-				Name: iterationName,
-				Kind: "Secret",
-				Target: konfigurev1alpha1.DisabledIterationTarget{
-					Name:      secret.Name,
-					Namespace: secret.Namespace,
-				},
-			})
-		}
+	var iterations []konfigurev1alpha1.IterationStatus
+	for _, iterationName := range iterationNames {
+		result := resultsByName[iterationName]
 
-		if err != nil {
-			logger.Error(err, fmt.Sprintf("Failed to apply secret %s/%s for app: %s", secret.Namespace, secret.Name, iterationName))
+		var iterationStatus konfigurev1alpha1.IterationStatus
+		if result.status != nil {
+			iterationStatus = *result.status
+		}
 
-			failures[iterationName] = err.Error()
+		// Failed iterations keep the references and hashes of their last successful reconciliation.
+		if failure, failed := failures[iterationName]; failed {
+			iterationStatus = previousIterationStatuses[iterationName]
+			iterationStatus.Name = iterationName
+			iterationStatus.Result = konfigurev1alpha1.IterationResultFailed
+			iterationStatus.RenderSkipped = false
+			iterationStatus.LastError = failure.IterationError()
+			iterationStatus.PendingChange = nil
+
+			RecordFailure(cr, iterationName, failure.Reason)
+		} else if pending[iterationName] {
+			// Iterations of waves not started yet keep the state of the previous revision.
+			iterationStatus = previousIterationStatuses[iterationName]
+			iterationStatus.Name = iterationName
+			iterationStatus.Result = konfigurev1alpha1.IterationResultPending
+			iterationStatus.RenderSkipped = false
+			iterationStatus.LastError = nil
+			iterationStatus.PendingChange = nil
+		} else if result.status == nil {
 			continue
 		}
 
-		logger.Info(fmt.Sprintf("Successfully reconciled rendered configmap and secret for: %s", iterationName))
-	}
+		iterationStatus.RenderDuration = result.renderDuration
+		iterationStatus.ApplyDuration = result.applyDuration
 
-	logger.Info(fmt.Sprintf("Failures: %s", failures))
-
-	cr.Status.Failed = []konfigurev1alpha1.FailedIteration{}
-	for failedIteration, failureMessage := range failures {
-		cr.Status.Failed = append(cr.Status.Failed, konfigurev1alpha1.FailedIteration{
-			Name:    failedIteration,
-			Message: failureMessage,
-		})
+		iterations = append(iterations, iterationStatus)
 	}
 
-	// Status update for disabled reconciliations
-	cr.Status.Disabled = disabledIterations
-
 	cr.Status.ObservedGeneration = cr.Generation
 	cr.Status.LastReconciledAt = time.Now().Format(time.RFC3339Nano)
+	cr.Status.Summary = logic.SummarizeIterations(iterations)
+
+	// The details of all iterations go to the report, so the status stays small regardless of the number of iterations.
+	reportReference, reportErr := r.writeReport(ctx, cr, logic.Report{
+		Revision:     revision,
+		Generation:   cr.Generation,
+		ReconciledAt: cr.Status.LastReconciledAt,
+		Summary:      cr.Status.Summary,
+		Iterations:   iterations,
+		Disabled:     disabledIterations,
+	})
+	if reportErr != nil {
+		logger.Error(reportErr, fmt.Sprintf("Failed to write the report of: %s/%s", cr.GetNamespace(), cr.GetName()))
+		r.Recorder.Eventf(cr, v1.EventTypeWarning, logic.ApplyFailedReason, "Failed to write report: %s", reportErr.Error())
+	} else {
+		cr.Status.Report = reportReference
+	}
 
 	cr.Status.LastAttemptedRevision = revision
 
-	cr.Status.Conditions = []metav1.Condition{}
-	if len(failures) == 0 {
+	if len(failures) > 0 {
+		cr.Status.ConsecutiveFailures++
+	} else {
+		cr.Status.ConsecutiveFailures = 0
+	}
+
+	logic.CountIterations(&run, iterations)
+
+	var rolloutRequeueAfter time.Duration
+	if rollout != nil && !awaitingApproval && holdReason == "" {
+		rolloutRequeueAfter = r.progressRollout(ctx, cr, rollout, waves, plansByName, failures)
+	}
+	cr.Status.Rollout = rollout
+
+	if awaitingApproval || held {
+		pendingRevision := logic.SummarizePendingRevision(cr.Status.PendingRevision, revision, iterations, metav1.Now())
+		pendingRevision.Reason = pendingReason
+		if holdReason == logic.OutsideMaintenanceWindowReason && !nextWindowAt.IsZero() {
+			pendingRevision.NextWindowAt = &metav1.Time{Time: nextWindowAt}
+		}
+
+		if cr.Status.PendingRevision == nil || cr.Status.PendingRevision.Revision != revision || cr.Status.PendingRevision.Reason != pendingReason {
+			r.Recorder.Event(cr, v1.EventTypeNormal, pendingReason, pendingRevisionMessage(pendingRevision))
+		}
+
+		cr.Status.PendingRevision = pendingRevision
+	} else {
+		cr.Status.PendingRevision = nil
+	}
+
+	meta.RemoveStatusCondition(&cr.Status.Conditions, logic.ReconcilingCondition)
+	meta.RemoveStatusCondition(&cr.Status.Conditions, logic.StalledCondition)
+
+	rolling := rollout != nil && rollout.Phase != konfigurev1alpha1.RolloutPhaseCompleted
+
+	if len(failures) == 0 && (awaitingApproval || held) {
+		message := pendingRevisionMessage(cr.Status.PendingRevision)
+
+		logic.SetCondition(&cr.Status.Conditions, logic.ReadyCondition, metav1.ConditionFalse, pendingReason, message, cr.Generation)
+		run.Reason = pendingReason
+	} else if len(failures) == 0 && holdReason != "" && rolling && rollout.Phase == konfigurev1alpha1.RolloutPhaseProgressing {
+		message := fmt.Sprintf("Rollout of revision: %s is held at wave: %s by the change freeze", revision, rollout.Wave)
+		if holdReason == logic.OutsideMaintenanceWindowReason {
+			message = fmt.Sprintf("Rollout of revision: %s is held at wave: %s %s", revision, rollout.Wave, nextWindowMessage(nextWindowAt))
+		}
+
+		logic.SetCondition(&cr.Status.Conditions, logic.ReadyCondition, metav1.ConditionFalse, holdReason, message, cr.Generation)
+		run.Reason = holdReason
+	} else if len(failures) == 0 && rolling {
+		reason := logic.RolloutProgressingReason
+		if rollout.Phase == konfigurev1alpha1.RolloutPhaseHalted {
+			// Only a new revision resumes the rollout.
+			reason = logic.RolloutHaltedReason
+			logic.SetCondition(&cr.Status.Conditions, logic.StalledCondition, metav1.ConditionTrue, reason, rollout.Message, cr.Generation)
+		} else {
+			logic.SetCondition(&cr.Status.Conditions, logic.ReconcilingCondition, metav1.ConditionTrue, reason, rollout.Message, cr.Generation)
+		}
+
+		logic.SetCondition(&cr.Status.Conditions, logic.ReadyCondition, metav1.ConditionFalse, reason, rollout.Message, cr.Generation)
+		run.Reason = reason
+	} else if len(failures) == 0 {
+		if cr.Status.LastAppliedRevision != revision {
+			r.Recorder.Eventf(cr, v1.EventTypeNormal, logic.ReconciliationSucceededReason, "Applied revision: %s", revision)
+		}
+
 		cr.Status.LastAppliedRevision = revision
 
-		cr.Status.Conditions = append(cr.Status.Conditions, metav1.Condition{
-			Type:               logic.ReadyCondition,
-			Status:             metav1.ConditionTrue,
-			ObservedGeneration: cr.Generation,
-			LastTransitionTime: metav1.NewTime(time.Now().UTC().Truncate(time.Second)),
-			Reason:             logic.ReconciliationSucceededReason,
-			Message:            fmt.Sprintf("Applied revision: %s", revision),
-		})
+		logic.SetCondition(&cr.Status.Conditions, logic.ReadyCondition, metav1.ConditionTrue, logic.ReconciliationSucceededReason, fmt.Sprintf("Applied revision: %s", revision), cr.Generation)
+		run.Reason = logic.ReconciliationSucceededReason
+	} else if errors.Is(runCtx.Err(), context.DeadlineExceeded) {
+		var timedOut int
+		for _, failure := range failures {
+			if failure.Reason == logic.TimedOutReason {
+				timedOut++
+			}
+		}
+
+		message := fmt.Sprintf("Attempted revision: %s, timed out after %s with %d of %d iterations completed", revision, timeout, len(iterationNames)-timedOut, len(iterationNames))
+		r.Recorder.Event(cr, v1.EventTypeWarning, logic.TimedOutReason, message)
+
+		logic.SetCondition(&cr.Status.Conditions, logic.ReadyCondition, metav1.ConditionFalse, logic.TimedOutReason, message, cr.Generation)
+		run.Reason = logic.TimedOutReason
 	} else {
-		cr.Status.Conditions = append(cr.Status.Conditions, metav1.Condition{
-			Type:               logic.ReadyCondition,
-			Status:             metav1.ConditionFalse,
-			ObservedGeneration: cr.Generation,
-			LastTransitionTime: metav1.NewTime(time.Now().UTC().Truncate(time.Second)),
-			Reason:             logic.ReconciliationFailedReason,
-			Message:            fmt.Sprintf("Attempted revision: %s", revision),
-		})
+		// Failures sharing a single cause are reported with its reason, a mix of causes with the generic one.
+		reason, breakdown := logic.SummarizeFailureReasons(slices.Collect(maps.Values(failures)))
+
+		logic.SetCondition(&cr.Status.Conditions, logic.ReadyCondition, metav1.ConditionFalse, reason, fmt.Sprintf("Attempted revision: %s, failed iterations: %s", revision, breakdown), cr.Generation)
+		run.Reason = reason
 	}
 
+	run.FinishedAt = metav1.Now()
+	cr.Status.History = logic.RecordRun(cr.Status.History, run, cr.Spec.Reconciliation.GetHistoryLimit())
+
 	err = r.Status().Update(ctx, cr)
 	if err != nil {
 		logger.Error(err, fmt.Sprintf("Failed to update status for: %s/%s", cr.GetNamespace(), cr.GetName()))
 		return ctrl.Result{}, err
 	}
 
+	// Retry with backoff, as the next reconciliation needs the report to tell the previous state of the iterations.
+	if reportErr != nil {
+		return ctrl.Result{}, reportErr
+	}
+
+	requeueAfter := r.requeueAfter(cr)
+	if rolloutRequeueAfter > 0 {
+		requeueAfter = min(requeueAfter, rolloutRequeueAfter)
+	}
+	if holdReason == logic.OutsideMaintenanceWindowReason && !nextWindowAt.IsZero() {
+		requeueAfter = min(requeueAfter, max(time.Until(nextWindowAt), time.Second))
+	}
+
 	if len(failures) > 0 {
-		logger.Info(fmt.Sprintf("Reconciliation finished in %s with %d failures, next run in %s", time.Since(reconcileStart).String(), len(failures), cr.Spec.Reconciliation.RetryInterval.Duration.String()))
+		logger.Info(fmt.Sprintf("Reconciliation finished in %s with %d failures, %d in a row, next run in %s", time.Since(reconcileStart).String(), len(failures), cr.Status.ConsecutiveFailures, requeueAfter.String()))
+
+		return ctrl.Result{RequeueAfter: requeueAfter}, nil
+	}
+
+	logger.Info(fmt.Sprintf("Reconciliation finished in %s, next run in %s", time.Since(reconcileStart).String(), requeueAfter.String()))
+
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
+}
 
-		return ctrl.Result{RequeueAfter: cr.Spec.Reconciliation.RetryInterval.Duration}, nil
+// pendingRevisionMessage describes the revision held back, why and the changes applying it would make.
+func pendingRevisionMessage(pendingRevision *konfigurev1alpha1.PendingRevision) string {
+	changes := fmt.Sprintf("applying it creates %d, updates %d and leaves %d iterations unchanged", pendingRevision.Created, pendingRevision.Updated, pendingRevision.Unchanged)
+
+	switch pendingRevision.Reason {
+	case logic.ChangeFreezeReason:
+		return fmt.Sprintf("Revision: %s is held back by the change freeze, %s", pendingRevision.Revision, changes)
+	case logic.OutsideMaintenanceWindowReason:
+		var nextWindowAt time.Time
+		if pendingRevision.NextWindowAt != nil {
+			nextWindowAt = pendingRevision.NextWindowAt.Time
+		}
+
+		return fmt.Sprintf("Revision: %s is held back %s, %s", pendingRevision.Revision, nextWindowMessage(nextWindowAt), changes)
+	default:
+		return fmt.Sprintf("Revision: %s awaits approval, %s, approve it by setting the %s annotation to it", pendingRevision.Revision, changes, logic.ApprovedRevisionAnnotation)
+	}
+}
+
+// nextWindowMessage tells until when the maintenance windows hold back new revisions.
+func nextWindowMessage(nextWindowAt time.Time) string {
+	if nextWindowAt.IsZero() {
+		return "as no maintenance window allows applying it"
 	}
 
-	logger.Info(fmt.Sprintf("Reconciliation finished in %s, next run in %s", time.Since(reconcileStart).String(), cr.Spec.Reconciliation.Interval.Duration.String()))
+	return fmt.Sprintf("until the next maintenance window at %s", nextWindowAt.UTC().Format(time.RFC3339))
+}
+
+// requeueAfter returns when to reconcile the Konfiguration next. Failed reconciliations are retried with exponential
+// backoff. Intervals are jittered, so Konfigurations created together spread their load on the API server and the
+// source-controller over time.
+func (r *KonfigurationReconciler) requeueAfter(cr *konfigurev1alpha1.Konfiguration) time.Duration {
+	reconciliation := cr.Spec.Reconciliation
+
+	interval := reconciliation.Interval.Duration
+	if cr.Status.ConsecutiveFailures > 0 {
+		interval = logic.BackoffInterval(reconciliation.GetRetryInterval(), reconciliation.GetMaxRetryInterval(), cr.Status.ConsecutiveFailures)
+	}
 
-	return ctrl.Result{RequeueAfter: cr.Spec.Reconciliation.Interval.Duration}, nil
+	return logic.JitterInterval(interval, r.Options.IntervalJitterPercentage)
 }
 
-func (r *KonfigurationReconciler) updateStatusOnSetupFailure(ctx context.Context, cr *konfigurev1alpha1.Konfiguration, err error) error {
+func (r *KonfigurationReconciler) updateStatusOnSetupFailure(ctx context.Context, cr *konfigurev1alpha1.Konfiguration, run konfigurev1alpha1.ReconciliationRun, step string, failure *logic.ClassifiedError, stalled bool) error {
+	log.FromContext(ctx).Error(failure, fmt.Sprintf("Failed to set up %s", step))
+	r.Recorder.Eventf(cr, v1.EventTypeWarning, logic.SetupFailedReason, "Failed to set up %s: %s", step, failure.Error())
+	RecordFailure(cr, "", failure.Reason)
+
 	cr.Status.ObservedGeneration = cr.Generation
 	cr.Status.LastReconciledAt = time.Now().Format(time.RFC3339Nano)
+	cr.Status.ConsecutiveFailures++
 
-	cr.Status.Conditions = []metav1.Condition{}
+	// Setup failures are retried, unless the failing step marked the reconciliation as stalled.
+	meta.RemoveStatusCondition(&cr.Status.Conditions, logic.ReconcilingCondition)
+	if !stalled {
+		meta.RemoveStatusCondition(&cr.Status.Conditions, logic.StalledCondition)
+	}
 
-	cr.Status.Conditions = append(cr.Status.Conditions, metav1.Condition{
-		Type:               logic.ReadyCondition,
-		Status:             metav1.ConditionFalse,
-		ObservedGeneration: cr.Generation,
-		LastTransitionTime: metav1.NewTime(time.Now().UTC().Truncate(time.Second)),
-		Reason:             logic.SetupFailedReason,
-		Message:            fmt.Sprintf("Setup failed: %s", err.Error()),
-	})
+	// Timeouts are reported as such, regardless of the step they hit.
+	reason := logic.SetupFailedReason
+	if failure.Reason == logic.TimedOutReason {
+		reason = logic.TimedOutReason
+	}
+
+	logic.SetCondition(&cr.Status.Conditions, logic.ReadyCondition, metav1.ConditionFalse, reason, fmt.Sprintf("Setup failed: %s", failure.Error()), cr.Generation)
+
+	run.Reason = reason
+	run.FinishedAt = metav1.Now()
+	cr.Status.History = logic.RecordRun(cr.Status.History, run, cr.Spec.Reconciliation.GetHistoryLimit())
 
 	return r.Status().Update(ctx, cr)
 }
 
-const (
-	KonfigurationSchemaDir = "/tmp/konfiguration-schemas"
-)
-
-func (r *KonfigurationReconciler) fetchKonfigurationSchema(ctx context.Context, spec konfigurev1alpha1.Schema) (string, error) {
+func (r *KonfigurationReconciler) fetchKonfigurationSchema(ctx context.Context, dir string, spec konfigurev1alpha1.Schema) (string, error) {
 	schema := &konfigurev1alpha1.KonfigurationSchema{}
 	err := r.Get(ctx, client.ObjectKey{Name: spec.Reference.Name, Namespace: spec.Reference.Namespace}, schema)
 	if apiMachineryErrors.IsNotFound(err) {
@@ -427,13 +740,13 @@ func (r *KonfigurationReconciler) fetchKonfigurationSchema(ctx context.Context,
 	prefix := fmt.Sprintf("%s-%s", spec.Reference.Namespace, spec.Reference.Name)
 
 	if schema.Spec.Raw.Remote.Url != "" {
-		return r.fetchKonfigurationSchemaFromUrl(ctx, prefix, schema.Spec.Raw.Remote.Url)
+		return r.fetchKonfigurationSchemaFromUrl(ctx, dir, prefix, schema.Spec.Raw.Remote.Url)
 	}
 
-	return r.saveKonfigurationSchemaRawContentToTempFile(prefix, schema.Spec.Raw.Content)
+	return r.saveKonfigurationSchemaRawContentToTempFile(dir, prefix, schema.Spec.Raw.Content)
 }
 
-func (r *KonfigurationReconciler) fetchKonfigurationSchemaFromUrl(ctx context.Context, prefix string, url string) (string, error) {
+func (r *KonfigurationReconciler) fetchKonfigurationSchemaFromUrl(ctx context.Context, dir string, prefix string, url string) (string, error) {
 	logger := log.FromContext(ctx)
 
 	request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
@@ -485,7 +798,7 @@ func (r *KonfigurationReconciler) fetchKonfigurationSchemaFromUrl(ctx context.Co
 		return "", err
 	}
 
-	file, err := os.CreateTemp(KonfigurationSchemaDir, prefix)
+	file, err := os.CreateTemp(dir, prefix)
 	if err != nil {
 		return "", err
 	}
@@ -499,8 +812,8 @@ func (r *KonfigurationReconciler) fetchKonfigurationSchemaFromUrl(ctx context.Co
 	return file.Name(), nil
 }
 
-func (r *KonfigurationReconciler) saveKonfigurationSchemaRawContentToTempFile(prefix string, content string) (string, error) {
-	file, err := os.CreateTemp(KonfigurationSchemaDir, prefix)
+func (r *KonfigurationReconciler) saveKonfigurationSchemaRawContentToTempFile(dir string, prefix string, content string) (string, error) {
+	file, err := os.CreateTemp(dir, prefix)
 	if err != nil {
 		return "", err
 	}
@@ -526,56 +839,152 @@ func (r *KonfigurationReconciler) canApplyConfigMap(ctx context.Context, configm
 	}
 
 	if err = logic.MatchOwnership(existingObject.ObjectMeta, configmap.ObjectMeta); err != nil {
-		return fmt.Errorf("desired configmap exists already and is owned by another object: %s", err.Error())
+		return &logic.OwnershipConflictError{Kind: "configmap", Err: err}
 	}
 
 	return nil
 }
 
-func (r *KonfigurationReconciler) applyConfigMap(ctx context.Context, generatedConfigMap *v1.ConfigMap) (bool, error) {
+// acquireSourceArtifact returns the latest artifact of the given Flux source from the artifact cache.
+func (r *KonfigurationReconciler) acquireSourceArtifact(ctx context.Context, fluxSource konfigurev1alpha1.FluxSource) (*Artifact, error) {
+	gitRepository := &unstructured.Unstructured{}
+
+	var err error
+	for _, gvk := range logic.GitRepositoryGVKs {
+		gitRepository.SetGroupVersionKind(gvk)
+
+		err = r.Get(ctx, client.ObjectKey{Name: fluxSource.GitRepository.Name, Namespace: fluxSource.GitRepository.Namespace}, gitRepository)
+		if !meta.IsNoMatchError(err) {
+			break
+		}
+	}
+
+	if apiMachineryErrors.IsNotFound(err) {
+		return nil, fmt.Errorf("GitRepository %s/%s not found", fluxSource.GitRepository.Namespace, fluxSource.GitRepository.Name)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	url, err := logic.ArtifactURL(gitRepository)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.Artifacts.Acquire(ctx, fluxSource, url)
+}
+
+// getReport returns the report of the last full reconciliation of the Konfiguration, or nil if there is none yet.
+func (r *KonfigurationReconciler) getReport(ctx context.Context, cr *konfigurev1alpha1.Konfiguration) (*logic.Report, error) {
+	configmap := &v1.ConfigMap{}
+
+	err := r.Get(ctx, client.ObjectKey{Name: logic.ReportName(cr.Name), Namespace: cr.Namespace}, configmap)
+	if apiMachineryErrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if configmap.Labels[logic.ReportLabel] != logic.ReportLabelValue || configmap.Labels[logic.OwnerNameLabel] != cr.Name {
+		return nil, fmt.Errorf("configmap %s/%s is not the report of the konfiguration", configmap.Namespace, configmap.Name)
+	}
+
+	return logic.DecodeReport(configmap)
+}
+
+// writeReport applies the report config map of the Konfiguration and returns its reference. The report is owned by
+// the Konfiguration, so it is garbage collected along with it.
+func (r *KonfigurationReconciler) writeReport(ctx context.Context, cr *konfigurev1alpha1.Konfiguration, report logic.Report) (*konfigurev1alpha1.ReportReference, error) {
+	gvk := konfigurev1alpha1.GroupVersion.WithKind("Konfiguration")
+
+	configmap, err := logic.ReportConfigMap(gvk, cr.ObjectMeta, report)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = r.canApplyConfigMap(ctx, configmap); err != nil {
+		return nil, err
+	}
+
+	configuration := logic.ConfigMapApplyConfiguration(configmap).
+		WithOwnerReferences(metav1ac.OwnerReference().
+			WithAPIVersion(gvk.GroupVersion().String()).
+			WithKind(gvk.Kind).
+			WithName(cr.Name).
+			WithUID(cr.UID).
+			WithController(true).
+			WithBlockOwnerDeletion(true))
+
+	if err = r.Apply(ctx, configuration, client.FieldOwner(logic.FieldManager), client.ForceOwnership); err != nil {
+		return nil, err
+	}
+
+	return &konfigurev1alpha1.ReportReference{Name: configmap.Name, Key: logic.ReportConfigMapKey(configmap)}, nil
+}
+
+// applyResult describes the outcome of applying a single rendered manifest.
+type applyResult struct {
+	// Outcome is empty when the manifest was not applied.
+	Outcome konfigurev1alpha1.ApplyOutcome
+
+	// ChangedKeys are the data keys that were added, removed or modified by the apply.
+	ChangedKeys []string
+}
+
+// applyConfigMap applies the generated config map and tells whether it should be reconciled and
+// what changed. Config maps whose data and metadata are up-to-date are not written.
+func (r *KonfigurationReconciler) applyConfigMap(ctx context.Context, generatedConfigMap *v1.ConfigMap) (bool, applyResult, error) {
 	existingObject := &v1.ConfigMap{}
 
 	err := r.Get(ctx, client.ObjectKeyFromObject(generatedConfigMap), existingObject)
 	if err != nil && !apiMachineryErrors.IsNotFound(err) {
-		return true, err
+		return true, applyResult{}, err
 	}
 
 	if !logic.ShouldReconcile(existingObject.ObjectMeta) {
-		return false, nil
+		return false, applyResult{}, nil
 	}
 
-	// Respect external annotations and labels.
-	// Do it this way to avoid keeping a removed or renamed konfigure-operator annotation or label being kept forever.
-	externalAnnotations := logic.FilterExternalFromMap(existingObject.Annotations)
-	externalLabels := logic.FilterExternalFromMap(existingObject.Labels)
+	// Create new manifests instead of applying them. Manifests not managed by the operator are missing from the cache,
+	// so this way they fail as already existing instead of being taken over.
+	if apiMachineryErrors.IsNotFound(err) {
+		if err = r.Create(ctx, generatedConfigMap.DeepCopy(), client.FieldOwner(logic.FieldManager)); err != nil {
+			return true, applyResult{}, err
+		}
 
-	desiredConfigMap := v1.ConfigMap{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:        generatedConfigMap.Name,
-			Namespace:   generatedConfigMap.Namespace,
-			Annotations: externalAnnotations,
-			Labels:      externalLabels,
-		},
+		return true, applyResult{
+			Outcome:     konfigurev1alpha1.ApplyOutcomeCreated,
+			ChangedKeys: logic.ChangedConfigMapKeys(nil, generatedConfigMap.Data),
+		}, nil
 	}
 
-	_, err = controllerutil.CreateOrUpdate(ctx, r.Client, &desiredConfigMap, func() error {
-		// Enforce desired annotations
-		for key, value := range generatedConfigMap.Annotations {
-			desiredConfigMap.Annotations[key] = value
-		}
+	changedKeys := logic.ChangedConfigMapKeys(existingObject.Data, generatedConfigMap.Data)
 
-		// Enforce desired labels
-		for key, value := range generatedConfigMap.Labels {
-			desiredConfigMap.Labels[key] = value
-		}
+	// Immutable manifests are named after their content, thus an existing one is up-to-date.
+	upToDate := ptr.Deref(generatedConfigMap.Immutable, false) ||
+		(len(changedKeys) == 0 &&
+			ptr.Equal(existingObject.Immutable, generatedConfigMap.Immutable) &&
+			logic.IsMetadataUpToDate(existingObject.ObjectMeta, generatedConfigMap.ObjectMeta))
+	if upToDate {
+		return true, applyResult{Outcome: konfigurev1alpha1.ApplyOutcomeUnchanged}, nil
+	}
 
-		// Always enforce data
-		desiredConfigMap.Data = generatedConfigMap.Data
+	if err = r.upgradeManagedFields(ctx, existingObject); err != nil {
+		return true, applyResult{}, err
+	}
 
-		return nil
-	})
+	err = r.Apply(ctx, logic.ConfigMapApplyConfiguration(generatedConfigMap), client.FieldOwner(logic.FieldManager))
+	if apiMachineryErrors.IsConflict(err) {
+		return true, applyResult{}, fmt.Errorf("desired configmap has fields managed by another field manager: %w", err)
+	}
 
-	return true, err
+	if err != nil {
+		return true, applyResult{}, err
+	}
+
+	return true, applyResult{Outcome: konfigurev1alpha1.ApplyOutcomeUpdated, ChangedKeys: changedKeys}, nil
 }
 
 func (r *KonfigurationReconciler) canApplySecret(ctx context.Context, generatedSecret *v1.Secret) error {
@@ -591,70 +1000,250 @@ func (r *KonfigurationReconciler) canApplySecret(ctx context.Context, generatedS
 	}
 
 	if err = logic.MatchOwnership(existingObject.ObjectMeta, generatedSecret.ObjectMeta); err != nil {
-		return fmt.Errorf("desired secret exists already and is owned by another object: %s", err.Error())
+		return &logic.OwnershipConflictError{Kind: "secret", Err: err}
 	}
 
 	return nil
 }
 
-func (r *KonfigurationReconciler) applySecret(ctx context.Context, generatedSecret *v1.Secret) (bool, error) {
+// applySecret applies the generated secret and tells whether it should be reconciled and
+// what changed. Secrets whose data and metadata are up-to-date are not written.
+func (r *KonfigurationReconciler) applySecret(ctx context.Context, generatedSecret *v1.Secret) (bool, applyResult, error) {
 	existingObject := &v1.Secret{}
 
 	err := r.Get(ctx, client.ObjectKeyFromObject(generatedSecret), existingObject)
 	if err != nil && !apiMachineryErrors.IsNotFound(err) {
-		return true, err
+		return true, applyResult{}, err
 	}
 
 	if !logic.ShouldReconcile(existingObject.ObjectMeta) {
-		return false, nil
+		return false, applyResult{}, nil
+	}
+
+	if apiMachineryErrors.IsNotFound(err) {
+		// Do not create secrets without content, but keep existing ones in sync.
+		if logic.IsEmptySecretData(generatedSecret.Data) {
+			return true, applyResult{Outcome: konfigurev1alpha1.ApplyOutcomeUnchanged}, nil
+		}
+
+		// Create new manifests instead of applying them. Manifests not managed by the operator are missing from
+		// the cache, so this way they fail as already existing instead of being taken over.
+		if err = r.Create(ctx, generatedSecret.DeepCopy(), client.FieldOwner(logic.FieldManager)); err != nil {
+			return true, applyResult{}, err
+		}
+
+		return true, applyResult{
+			Outcome:     konfigurev1alpha1.ApplyOutcomeCreated,
+			ChangedKeys: logic.ChangedSecretKeys(nil, generatedSecret.Data),
+		}, nil
 	}
 
-	// Respect external annotations and labels.
-	// Do it this way to avoid keeping a removed or renamed konfigure-operator annotation or label being kept forever.
-	externalAnnotations := logic.FilterExternalFromMap(existingObject.Annotations)
-	externalLabels := logic.FilterExternalFromMap(existingObject.Labels)
+	changedKeys := logic.ChangedSecretKeys(existingObject.Data, generatedSecret.Data)
+
+	// Immutable manifests are named after their content, thus an existing one is up-to-date.
+	upToDate := ptr.Deref(generatedSecret.Immutable, false) ||
+		(len(changedKeys) == 0 &&
+			ptr.Equal(existingObject.Immutable, generatedSecret.Immutable) &&
+			logic.IsMetadataUpToDate(existingObject.ObjectMeta, generatedSecret.ObjectMeta))
+	if upToDate {
+		return true, applyResult{Outcome: konfigurev1alpha1.ApplyOutcomeUnchanged}, nil
+	}
+
+	if err = r.upgradeManagedFields(ctx, existingObject); err != nil {
+		return true, applyResult{}, err
+	}
 
-	desiredSecret := v1.Secret{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:        generatedSecret.Name,
-			Namespace:   generatedSecret.Namespace,
-			Annotations: externalAnnotations,
-			Labels:      externalLabels,
-		},
+	err = r.Apply(ctx, logic.SecretApplyConfiguration(generatedSecret), client.FieldOwner(logic.FieldManager))
+	if apiMachineryErrors.IsConflict(err) {
+		return true, applyResult{}, fmt.Errorf("desired secret has fields managed by another field manager: %w", err)
 	}
 
-	_, err = controllerutil.CreateOrUpdate(ctx, r.Client, &desiredSecret, func() error {
-		// Enforce desired annotations
-		for key, value := range generatedSecret.Annotations {
-			desiredSecret.Annotations[key] = value
+	if err != nil {
+		return true, applyResult{}, err
+	}
+
+	return true, applyResult{Outcome: konfigurev1alpha1.ApplyOutcomeUpdated, ChangedKeys: changedKeys}, nil
+}
+
+// recordPreflightFailure emits an event for a failed pre-flight check, telling ownership conflicts apart.
+func (r *KonfigurationReconciler) recordPreflightFailure(cr *konfigurev1alpha1.Konfiguration, iterationName string, err error) {
+	var conflict *logic.OwnershipConflictError
+	if errors.As(err, &conflict) {
+		r.Recorder.Eventf(cr, v1.EventTypeWarning, logic.OwnershipConflictReason, "Ownership conflict for iteration %s: %s", iterationName, err.Error())
+		return
+	}
+
+	r.Recorder.Eventf(cr, v1.EventTypeWarning, logic.ApplyFailedReason, "Failed to apply iteration %s: %s", iterationName, err.Error())
+}
+
+// recordApply records the outcome of applying a rendered manifest and emits an event listing the changed keys.
+// Values are never part of the event, as they may be secret.
+func (r *KonfigurationReconciler) recordApply(cr *konfigurev1alpha1.Konfiguration, iterationName, kind string, meta metav1.ObjectMeta, result applyResult) {
+	RecordApply(cr, iterationName, kind, result.Outcome)
+
+	if len(result.ChangedKeys) == 0 {
+		return
+	}
+
+	keys := strings.Join(result.ChangedKeys, ", ")
+
+	switch result.Outcome {
+	case konfigurev1alpha1.ApplyOutcomeCreated:
+		r.Recorder.Eventf(cr, v1.EventTypeNormal, logic.ManifestCreatedReason, "Created %s %s/%s for iteration %s with keys: %s", kind, meta.Namespace, meta.Name, iterationName, keys)
+	case konfigurev1alpha1.ApplyOutcomeUpdated:
+		r.Recorder.Eventf(cr, v1.EventTypeNormal, logic.ManifestUpdatedReason, "Updated %s %s/%s for iteration %s, changed keys: %s", kind, meta.Namespace, meta.Name, iterationName, keys)
+	}
+}
+
+// upgradeManagedFields hands the fields set by client-side updates of the operator over to its server-side apply
+// field manager, so fields dropped from the rendered manifest are removed instead of being kept forever.
+func (r *KonfigurationReconciler) upgradeManagedFields(ctx context.Context, existingObject client.Object) error {
+	patch, err := csaupgrade.UpgradeManagedFieldsPatch(existingObject, logic.ClientSideFieldManagers(), logic.FieldManager)
+	if err != nil || patch == nil {
+		return err
+	}
+
+	return r.Patch(ctx, existingObject, client.RawPatch(types.JSONPatchType, patch))
+}
+
+// triggerRollouts notifies the workloads of the rollout triggers applying to the given iteration about
+// the changed checksum of its data. Workloads that do not exist yet are skipped, as they will pick up
+// the current data once created.
+func (r *KonfigurationReconciler) triggerRollouts(ctx context.Context, triggers []konfigurev1alpha1.RolloutTrigger, iterationName, targetNamespace string, variables map[string]string, checksum string) error {
+	logger := log.FromContext(ctx)
+
+	for _, trigger := range triggers {
+		if !trigger.AppliesTo(iterationName) {
+			continue
 		}
 
-		// Enforce desired labels
-		for key, value := range generatedSecret.Labels {
-			desiredSecret.Labels[key] = value
+		subject := fmt.Sprintf("rollout trigger %s \"%s\"", trigger.Kind, trigger.Name)
+
+		name, err := logic.Interpolate(subject, trigger.Name, variables)
+		if err != nil {
+			return err
 		}
 
-		// Always enforce data
-		desiredSecret.Data = generatedSecret.Data
-		desiredSecret.StringData = generatedSecret.StringData
+		namespace, err := logic.Interpolate(subject, cmp.Or(trigger.Namespace, targetNamespace), variables)
+		if err != nil {
+			return err
+		}
 
-		return nil
-	})
+		gvk, err := logic.RolloutTriggerGVK(trigger.Kind)
+		if err != nil {
+			return err
+		}
+
+		patch, err := logic.RolloutTriggerPatch(trigger.Kind, checksum, time.Now())
+		if err != nil {
+			return err
+		}
+
+		target := &unstructured.Unstructured{}
+		target.SetGroupVersionKind(gvk)
+		target.SetName(name)
+		target.SetNamespace(namespace)
+
+		err = r.Patch(ctx, target, client.RawPatch(types.MergePatchType, patch))
+		if apiMachineryErrors.IsNotFound(err) {
+			logger.Info(fmt.Sprintf("Skipping rollout trigger for %s %s/%s as it does not exist", trigger.Kind, namespace, name))
+			continue
+		}
+
+		if err != nil {
+			return fmt.Errorf("failed to trigger rollout of %s %s/%s: %w", trigger.Kind, namespace, name, err)
+		}
+
+		logger.Info(fmt.Sprintf("Triggered rollout of %s %s/%s for app: %s", trigger.Kind, namespace, name, iterationName))
+	}
+
+	return nil
+}
+
+// pruneImmutableGenerations deletes the previous generations of the immutable config map and secret of an iteration
+// that exceed the number of generations to retain.
+func (r *KonfigurationReconciler) pruneImmutableGenerations(ctx context.Context, output konfigurev1alpha1.Output, configmap *v1.ConfigMap, secret *v1.Secret, retain int) error {
+	if output.RendersConfigMap() {
+		configmaps := &v1.ConfigMapList{}
+		if err := r.List(ctx, configmaps, client.InNamespace(configmap.Namespace), immutableGenerationSelector(configmap.ObjectMeta)); err != nil {
+			return err
+		}
+
+		var generations []metav1.ObjectMeta
+		for _, item := range configmaps.Items {
+			if item.Annotations[logic.BaseNameAnnotation] == configmap.Annotations[logic.BaseNameAnnotation] {
+				generations = append(generations, item.ObjectMeta)
+			}
+		}
+
+		for _, generation := range logic.SelectPrunableGenerations(generations, configmap.Name, retain) {
+			err := r.Delete(ctx, &v1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: generation.Name, Namespace: generation.Namespace}})
+			if client.IgnoreNotFound(err) != nil {
+				return err
+			}
+		}
+	}
+
+	if output.RendersSecret() {
+		secrets := &v1.SecretList{}
+		if err := r.List(ctx, secrets, client.InNamespace(secret.Namespace), immutableGenerationSelector(secret.ObjectMeta)); err != nil {
+			return err
+		}
 
-	return true, err
+		var generations []metav1.ObjectMeta
+		for _, item := range secrets.Items {
+			if item.Annotations[logic.BaseNameAnnotation] == secret.Annotations[logic.BaseNameAnnotation] {
+				generations = append(generations, item.ObjectMeta)
+			}
+		}
+
+		for _, generation := range logic.SelectPrunableGenerations(generations, secret.Name, retain) {
+			err := r.Delete(ctx, &v1.Secret{ObjectMeta: metav1.ObjectMeta{Name: generation.Name, Namespace: generation.Namespace}})
+			if client.IgnoreNotFound(err) != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// immutableGenerationSelector matches all immutable generations rendered by the same owner.
+func immutableGenerationSelector(meta metav1.ObjectMeta) client.MatchingLabels {
+	return client.MatchingLabels{
+		logic.GeneratedByLabel:    meta.Labels[logic.GeneratedByLabel],
+		logic.OwnerApiGroupLabel:  meta.Labels[logic.OwnerApiGroupLabel],
+		logic.OwnerKindLabel:      meta.Labels[logic.OwnerKindLabel],
+		logic.OwnerNameLabel:      meta.Labels[logic.OwnerNameLabel],
+		logic.OwnerNamespaceLabel: meta.Labels[logic.OwnerNamespaceLabel],
+		logic.ImmutableLabel:      logic.ImmutableLabelValue,
+	}
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *KonfigurationReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	err := os.MkdirAll(KonfigurationSchemaDir, 0700)
-	if err != nil {
+	if r.Workspaces == nil {
+		r.Workspaces = NewWorkspaceManager(DefaultWorkspaceDir, DefaultSopsKeysDir)
+	}
+
+	if r.APIReader == nil {
+		r.APIReader = mgr.GetAPIReader()
+	}
+
+	if r.Artifacts == nil {
+		r.Artifacts = NewArtifactCache(DefaultArtifactCacheDir, cmp.Or(r.Options.ArtifactCacheMaxSize, DefaultArtifactCacheMaxSize))
+	}
+
+	if err := r.Artifacts.Reset(); err != nil {
 		return err
 	}
 
 	return ctrl.NewControllerManagedBy(mgr).
+		// Annotations approve revisions, so they are picked up right away.
 		For(&konfigurev1alpha1.Konfiguration{}, builder.WithPredicates(
-			predicate.GenerationChangedPredicate{},
+			predicate.Or(predicate.GenerationChangedPredicate{}, predicate.AnnotationChangedPredicate{}),
 		)).
+		WithOptions(controller.Options{MaxConcurrentReconciles: r.Options.MaxConcurrentReconciles}).
 		Named("konfiguration").
 		Complete(r)
 }
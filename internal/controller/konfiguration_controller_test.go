@@ -68,11 +68,9 @@ includes: []`
 )
 
 func TestFetchKonfigurationSchemaFromUrl(t *testing.T) {
-	err := os.Mkdir(KonfigurationSchemaDir, 0750)
-	if err != nil && !os.IsExist(err) {
-		t.Fatalf("error creating %v directory: %v", KonfigurationSchemaDir, err)
-	}
+	dir := t.TempDir()
 
+	var err error
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case "/schema-good":
@@ -145,7 +143,7 @@ func TestFetchKonfigurationSchemaFromUrl(t *testing.T) {
 
 	for i, tc := range testCases {
 		t.Run(fmt.Sprintf("case %d: %s", i, tc.name), func(t *testing.T) {
-			file, err := rc.fetchKonfigurationSchemaFromUrl(context.Background(), "testing", tc.url)
+			file, err := rc.fetchKonfigurationSchemaFromUrl(context.Background(), dir, "testing", tc.url)
 
 			if err != nil {
 				if tc.expectedErr == nil && !tc.wantAnyErr {
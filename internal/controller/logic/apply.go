@@ -0,0 +1,53 @@
+package logic
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	corev1ac "k8s.io/client-go/applyconfigurations/core/v1"
+)
+
+const (
+	// FieldManager is the field manager the operator uses to apply rendered manifests with server-side apply.
+	FieldManager = "konfigure-operator"
+
+	// legacyFieldManager is the field manager derived from the binary name by previous versions of the operator
+	// that updated rendered manifests with client-side updates.
+	legacyFieldManager = "manager"
+)
+
+// ClientSideFieldManagers returns the field managers whose fields set by client-side updates are taken over by
+// FieldManager before applying. Manifests are created with FieldManager via client-side create, thus it is included.
+func ClientSideFieldManagers() sets.Set[string] {
+	return sets.New(legacyFieldManager, FieldManager)
+}
+
+// ConfigMapApplyConfiguration returns the server-side apply configuration with the fields owned by the operator
+// of the rendered config map.
+func ConfigMapApplyConfiguration(configmap *corev1.ConfigMap) *corev1ac.ConfigMapApplyConfiguration {
+	configuration := corev1ac.ConfigMap(configmap.Name, configmap.Namespace).
+		WithLabels(configmap.Labels).
+		WithAnnotations(configmap.Annotations).
+		WithData(configmap.Data).
+		WithBinaryData(configmap.BinaryData)
+
+	if configmap.Immutable != nil {
+		configuration.WithImmutable(*configmap.Immutable)
+	}
+
+	return configuration
+}
+
+// SecretApplyConfiguration returns the server-side apply configuration with the fields owned by the operator
+// of the rendered secret.
+func SecretApplyConfiguration(secret *corev1.Secret) *corev1ac.SecretApplyConfiguration {
+	configuration := corev1ac.Secret(secret.Name, secret.Namespace).
+		WithLabels(secret.Labels).
+		WithAnnotations(secret.Annotations).
+		WithData(secret.Data)
+
+	if secret.Immutable != nil {
+		configuration.WithImmutable(*secret.Immutable)
+	}
+
+	return configuration
+}
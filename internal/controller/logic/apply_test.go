@@ -0,0 +1,71 @@
+package logic
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestConfigMapApplyConfiguration(t *testing.T) {
+	configmap := &corev1.ConfigMap{
+		ObjectMeta: v1.ObjectMeta{
+			Name:        "app-operator",
+			Namespace:   "giantswarm",
+			Labels:      map[string]string{GeneratedByLabel: GeneratedByLabelValue},
+			Annotations: map[string]string{ChecksumAnnotation: "abc"},
+		},
+		Data: map[string]string{"configmap-values.yaml": "foo: bar\n"},
+	}
+
+	result := ConfigMapApplyConfiguration(configmap)
+
+	if *result.Name != "app-operator" || *result.Namespace != "giantswarm" || *result.Kind != "ConfigMap" || *result.APIVersion != "v1" {
+		t.Fatalf("unexpected object reference: %s %s %s/%s", *result.APIVersion, *result.Kind, *result.Namespace, *result.Name)
+	}
+
+	if result.Labels[GeneratedByLabel] != GeneratedByLabelValue || result.Annotations[ChecksumAnnotation] != "abc" {
+		t.Fatalf("labels and annotations should be applied, got labels: %v, annotations: %v", result.Labels, result.Annotations)
+	}
+
+	if result.Data["configmap-values.yaml"] != "foo: bar\n" {
+		t.Fatalf("data should be applied, got: %v", result.Data)
+	}
+
+	// Leave immutability to other field managers unless the operator renders immutable manifests.
+	if result.Immutable != nil {
+		t.Fatalf("immutable should not be applied")
+	}
+
+	configmap.Immutable = ptr.To(true)
+
+	if result = ConfigMapApplyConfiguration(configmap); result.Immutable == nil || !*result.Immutable {
+		t.Fatalf("immutable should be applied")
+	}
+}
+
+func TestSecretApplyConfiguration(t *testing.T) {
+	secret := &corev1.Secret{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "app-operator",
+			Namespace: "giantswarm",
+			Labels:    map[string]string{GeneratedByLabel: GeneratedByLabelValue},
+		},
+		Data: map[string][]byte{"secret-values.yaml": []byte("foo: bar\n")},
+	}
+
+	result := SecretApplyConfiguration(secret)
+
+	if *result.Name != "app-operator" || *result.Namespace != "giantswarm" || *result.Kind != "Secret" || *result.APIVersion != "v1" {
+		t.Fatalf("unexpected object reference: %s %s %s/%s", *result.APIVersion, *result.Kind, *result.Namespace, *result.Name)
+	}
+
+	if result.Labels[GeneratedByLabel] != GeneratedByLabelValue || string(result.Data["secret-values.yaml"]) != "foo: bar\n" {
+		t.Fatalf("labels and data should be applied, got labels: %v, data: %v", result.Labels, result.Data)
+	}
+
+	if result.Type != nil || result.Immutable != nil {
+		t.Fatalf("type and immutable should be left to other field managers")
+	}
+}
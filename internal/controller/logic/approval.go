@@ -0,0 +1,74 @@
+package logic
+
+import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	konfigurev1alpha1 "github.com/giantswarm/konfigure-operator/api/v1alpha1"
+)
+
+const (
+	// ApprovedRevisionAnnotation approves the revision it is set to for the Konfiguration it is set on.
+	ApprovedRevisionAnnotation = KonfigureOperatorPrefix + "/approved-revision"
+)
+
+// AwaitsApproval tells whether the given revision must not be applied yet: the Konfiguration requires manual approval,
+// and the revision is neither approved nor applied or being rolled out already.
+func AwaitsApproval(cr *konfigurev1alpha1.Konfiguration, revision string) bool {
+	if cr.Spec.Reconciliation.Approval != konfigurev1alpha1.ApprovalPolicyManual {
+		return false
+	}
+
+	if revision == cr.Status.LastAppliedRevision || cr.GetAnnotations()[ApprovedRevisionAnnotation] == revision {
+		return false
+	}
+
+	// Rollouts only start once the revision is approved, so withdrawing the approval does not stop them.
+	return cr.Status.Rollout == nil || cr.Status.Rollout.Revision != revision
+}
+
+// AddPendingChange adds the changes to the data of a single manifest to the pending change of an iteration.
+func AddPendingChange(change *konfigurev1alpha1.PendingChange, kind string, exists bool, changedKeys []string) {
+	outcome := konfigurev1alpha1.ApplyOutcomeUnchanged
+	if !exists {
+		outcome = konfigurev1alpha1.ApplyOutcomeCreated
+	} else if len(changedKeys) > 0 {
+		outcome = konfigurev1alpha1.ApplyOutcomeUpdated
+	}
+
+	change.Outcome = change.Outcome.Merge(outcome)
+
+	for _, key := range changedKeys {
+		change.ChangedKeys = append(change.ChangedKeys, kind+"/"+key)
+	}
+}
+
+// SummarizePendingRevision counts the pending changes of the iterations for the given revision. The time the revision
+// was first rendered is kept from the current pending revision, if it is the same.
+func SummarizePendingRevision(current *konfigurev1alpha1.PendingRevision, revision string, iterations []konfigurev1alpha1.IterationStatus, now v1.Time) *konfigurev1alpha1.PendingRevision {
+	pending := &konfigurev1alpha1.PendingRevision{Revision: revision, Since: now}
+	if current != nil && current.Revision == revision {
+		pending.Since = current.Since
+	}
+
+	for _, iteration := range iterations {
+		if iteration.Result == konfigurev1alpha1.IterationResultFailed {
+			pending.Failed++
+			continue
+		}
+
+		if iteration.PendingChange == nil || iteration.PendingChange.Revision != revision {
+			continue
+		}
+
+		switch iteration.PendingChange.Outcome {
+		case konfigurev1alpha1.ApplyOutcomeCreated:
+			pending.Created++
+		case konfigurev1alpha1.ApplyOutcomeUpdated:
+			pending.Updated++
+		case konfigurev1alpha1.ApplyOutcomeUnchanged:
+			pending.Unchanged++
+		}
+	}
+
+	return pending
+}
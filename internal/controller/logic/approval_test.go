@@ -0,0 +1,150 @@
+package logic
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	konfigurev1alpha1 "github.com/giantswarm/konfigure-operator/api/v1alpha1"
+)
+
+func TestAwaitsApproval(t *testing.T) {
+	testCases := []struct {
+		name        string
+		approval    konfigurev1alpha1.ApprovalPolicy
+		annotations map[string]string
+		status      konfigurev1alpha1.KonfigurationStatus
+		expected    bool
+	}{
+		{
+			name:     "automatic approval",
+			approval: konfigurev1alpha1.ApprovalPolicyAutomatic,
+			expected: false,
+		},
+		{
+			name:     "new revision not approved",
+			approval: konfigurev1alpha1.ApprovalPolicyManual,
+			status:   konfigurev1alpha1.KonfigurationStatus{LastAppliedRevision: "a"},
+			expected: true,
+		},
+		{
+			name:        "other revision approved",
+			approval:    konfigurev1alpha1.ApprovalPolicyManual,
+			annotations: map[string]string{ApprovedRevisionAnnotation: "a"},
+			status:      konfigurev1alpha1.KonfigurationStatus{LastAppliedRevision: "a"},
+			expected:    true,
+		},
+		{
+			name:        "new revision approved",
+			approval:    konfigurev1alpha1.ApprovalPolicyManual,
+			annotations: map[string]string{ApprovedRevisionAnnotation: "b"},
+			status:      konfigurev1alpha1.KonfigurationStatus{LastAppliedRevision: "a"},
+			expected:    false,
+		},
+		{
+			name:     "revision applied already",
+			approval: konfigurev1alpha1.ApprovalPolicyManual,
+			status:   konfigurev1alpha1.KonfigurationStatus{LastAppliedRevision: "b"},
+			expected: false,
+		},
+		{
+			name:     "revision being rolled out",
+			approval: konfigurev1alpha1.ApprovalPolicyManual,
+			status: konfigurev1alpha1.KonfigurationStatus{
+				LastAppliedRevision: "a",
+				Rollout:             &konfigurev1alpha1.RolloutStatus{Revision: "b", Phase: konfigurev1alpha1.RolloutPhaseProgressing},
+			},
+			expected: false,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("case %d: %s", i, tc.name), func(t *testing.T) {
+			cr := &konfigurev1alpha1.Konfiguration{
+				ObjectMeta: v1.ObjectMeta{Annotations: tc.annotations},
+				Spec: konfigurev1alpha1.KonfigurationSpec{
+					Reconciliation: konfigurev1alpha1.Reconciliation{Approval: tc.approval},
+				},
+				Status: tc.status,
+			}
+
+			result := AwaitsApproval(cr, "b")
+
+			if result != tc.expected {
+				t.Fatalf("expected %t, got %t", tc.expected, result)
+			}
+		})
+	}
+}
+
+func TestAddPendingChange(t *testing.T) {
+	change := &konfigurev1alpha1.PendingChange{Revision: "b", Outcome: konfigurev1alpha1.ApplyOutcomeUnchanged}
+
+	AddPendingChange(change, "ConfigMap", true, nil)
+	if change.Outcome != konfigurev1alpha1.ApplyOutcomeUnchanged {
+		t.Fatalf("expected %s, got %s", konfigurev1alpha1.ApplyOutcomeUnchanged, change.Outcome)
+	}
+
+	AddPendingChange(change, "ConfigMap", true, []string{"configmap-values.yaml"})
+	if change.Outcome != konfigurev1alpha1.ApplyOutcomeUpdated {
+		t.Fatalf("expected %s, got %s", konfigurev1alpha1.ApplyOutcomeUpdated, change.Outcome)
+	}
+
+	AddPendingChange(change, "Secret", false, []string{"secret-values.yaml"})
+	if change.Outcome != konfigurev1alpha1.ApplyOutcomeCreated {
+		t.Fatalf("expected %s, got %s", konfigurev1alpha1.ApplyOutcomeCreated, change.Outcome)
+	}
+
+	expected := []string{"ConfigMap/configmap-values.yaml", "Secret/secret-values.yaml"}
+	if !reflect.DeepEqual(change.ChangedKeys, expected) {
+		t.Fatalf("expected %v, got %v", expected, change.ChangedKeys)
+	}
+}
+
+func TestSummarizePendingRevision(t *testing.T) {
+	since := v1.NewTime(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	now := v1.NewTime(since.Add(time.Hour))
+
+	iterations := []konfigurev1alpha1.IterationStatus{
+		{Name: "a", Result: konfigurev1alpha1.IterationResultPending, PendingChange: &konfigurev1alpha1.PendingChange{Revision: "b", Outcome: konfigurev1alpha1.ApplyOutcomeCreated}},
+		{Name: "b", Result: konfigurev1alpha1.IterationResultPending, PendingChange: &konfigurev1alpha1.PendingChange{Revision: "b", Outcome: konfigurev1alpha1.ApplyOutcomeUpdated}},
+		{Name: "c", Result: konfigurev1alpha1.IterationResultPending, PendingChange: &konfigurev1alpha1.PendingChange{Revision: "b", Outcome: konfigurev1alpha1.ApplyOutcomeUnchanged}},
+		{Name: "d", Result: konfigurev1alpha1.IterationResultPending, PendingChange: &konfigurev1alpha1.PendingChange{Revision: "a", Outcome: konfigurev1alpha1.ApplyOutcomeUpdated}},
+		{Name: "e", Result: konfigurev1alpha1.IterationResultFailed},
+	}
+
+	testCases := []struct {
+		name          string
+		current       *konfigurev1alpha1.PendingRevision
+		expectedSince v1.Time
+	}{
+		{
+			name:          "first render of the revision",
+			expectedSince: now,
+		},
+		{
+			name:          "revision replacing another pending one",
+			current:       &konfigurev1alpha1.PendingRevision{Revision: "a", Since: since},
+			expectedSince: now,
+		},
+		{
+			name:          "revision pending already",
+			current:       &konfigurev1alpha1.PendingRevision{Revision: "b", Since: since},
+			expectedSince: since,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("case %d: %s", i, tc.name), func(t *testing.T) {
+			result := SummarizePendingRevision(tc.current, "b", iterations, now)
+
+			expected := &konfigurev1alpha1.PendingRevision{Revision: "b", Since: tc.expectedSince, Created: 1, Updated: 1, Unchanged: 1, Failed: 1}
+			if !reflect.DeepEqual(result, expected) {
+				t.Fatalf("expected %+v, got %+v", expected, result)
+			}
+		})
+	}
+}
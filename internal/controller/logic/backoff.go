@@ -0,0 +1,29 @@
+package logic
+
+import (
+	"math/rand/v2"
+	"time"
+)
+
+// BackoffInterval returns the interval before retrying a reconciliation after the given number of consecutive
+// failures. The retry interval doubles with each failure after the first one, up to the maximum interval.
+func BackoffInterval(retryInterval, maxInterval time.Duration, failures int32) time.Duration {
+	interval := retryInterval
+	for i := int32(1); i < failures && interval < maxInterval; i++ {
+		interval *= 2
+	}
+
+	return min(interval, maxInterval)
+}
+
+// JitterInterval shifts the interval randomly by up to the given percentage in either direction, so Konfigurations
+// created together do not keep reconciling at the same moment.
+func JitterInterval(interval time.Duration, percentage int) time.Duration {
+	if percentage <= 0 || interval <= 0 {
+		return interval
+	}
+
+	spread := float64(interval) * float64(percentage) / 100
+
+	return interval + time.Duration(spread*(2*rand.Float64()-1))
+}
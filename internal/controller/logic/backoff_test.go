@@ -0,0 +1,65 @@
+package logic
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestBackoffInterval(t *testing.T) {
+	testCases := []struct {
+		name     string
+		failures int32
+		expected time.Duration
+	}{
+		{
+			name:     "no failures",
+			failures: 0,
+			expected: time.Minute,
+		},
+		{
+			name:     "first failure",
+			failures: 1,
+			expected: time.Minute,
+		},
+		{
+			name:     "doubles with each failure",
+			failures: 3,
+			expected: 4 * time.Minute,
+		},
+		{
+			name:     "capped at the maximum",
+			failures: 5,
+			expected: 10 * time.Minute,
+		},
+		{
+			name:     "capped on many failures",
+			failures: 1000,
+			expected: 10 * time.Minute,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("case %d: %s", i, tc.name), func(t *testing.T) {
+			result := BackoffInterval(time.Minute, 10*time.Minute, tc.failures)
+
+			if result != tc.expected {
+				t.Fatalf("expected %s, got %s", tc.expected, result)
+			}
+		})
+	}
+}
+
+func TestJitterInterval(t *testing.T) {
+	if result := JitterInterval(time.Minute, 0); result != time.Minute {
+		t.Fatalf("expected no jitter, got %s", result)
+	}
+
+	for range 100 {
+		result := JitterInterval(time.Minute, 10)
+
+		if result < 54*time.Second || result > 66*time.Second {
+			t.Fatalf("expected interval within 10%% of 1m, got %s", result)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package logic
+
+import (
+	"bytes"
+	"maps"
+	"slices"
+
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ChangedConfigMapKeys returns the sorted keys that were added, removed or modified between the live and
+// the desired config map data.
+func ChangedConfigMapKeys(live, desired map[string]string) []string {
+	return changedKeys(live, desired, func(a, b string) bool { return a == b })
+}
+
+// ChangedSecretKeys returns the sorted keys that were added, removed or modified between the live and
+// the desired secret data.
+func ChangedSecretKeys(live, desired map[string][]byte) []string {
+	return changedKeys(live, desired, bytes.Equal)
+}
+
+// IsMetadataUpToDate tells whether all desired labels and annotations are set on the live manifest.
+// The revision label is ignored, as a new revision alone does not justify writing the manifest.
+func IsMetadataUpToDate(live, desired v1.ObjectMeta) bool {
+	for key, value := range desired.Labels {
+		if key == RevisionLabel {
+			continue
+		}
+
+		if liveValue, ok := live.Labels[key]; !ok || liveValue != value {
+			return false
+		}
+	}
+
+	for key, value := range desired.Annotations {
+		if liveValue, ok := live.Annotations[key]; !ok || liveValue != value {
+			return false
+		}
+	}
+
+	return true
+}
+
+func changedKeys[V any](live, desired map[string]V, equal func(a, b V) bool) []string {
+	changed := make(map[string]struct{})
+
+	for key, desiredValue := range desired {
+		if liveValue, ok := live[key]; !ok || !equal(liveValue, desiredValue) {
+			changed[key] = struct{}{}
+		}
+	}
+
+	for key := range live {
+		if _, ok := desired[key]; !ok {
+			changed[key] = struct{}{}
+		}
+	}
+
+	return slices.Sorted(maps.Keys(changed))
+}
@@ -0,0 +1,112 @@
+package logic
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestChangedConfigMapKeys(t *testing.T) {
+	testCases := []struct {
+		name     string
+		live     map[string]string
+		desired  map[string]string
+		expected []string
+	}{
+		{
+			name:     "identical data",
+			live:     map[string]string{"a": "1", "b": "2"},
+			desired:  map[string]string{"a": "1", "b": "2"},
+			expected: nil,
+		},
+		{
+			name:     "created from nothing",
+			live:     nil,
+			desired:  map[string]string{"b": "2", "a": "1"},
+			expected: []string{"a", "b"},
+		},
+		{
+			name:     "added, removed and modified keys",
+			live:     map[string]string{"a": "1", "b": "2", "c": "3"},
+			desired:  map[string]string{"a": "1", "b": "x", "d": "4"},
+			expected: []string{"b", "c", "d"},
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("case %d: %s", i, tc.name), func(t *testing.T) {
+			result := ChangedConfigMapKeys(tc.live, tc.desired)
+
+			if !reflect.DeepEqual(result, tc.expected) {
+				t.Fatalf("expected result: %v, got: %v", tc.expected, result)
+			}
+		})
+	}
+}
+
+func TestChangedSecretKeys(t *testing.T) {
+	result := ChangedSecretKeys(
+		map[string][]byte{"a": []byte("1"), "b": []byte("2")},
+		map[string][]byte{"a": []byte("1"), "b": []byte("3")},
+	)
+
+	if !reflect.DeepEqual(result, []string{"b"}) {
+		t.Fatalf("expected result: [b], got: %v", result)
+	}
+}
+
+func TestIsMetadataUpToDate(t *testing.T) {
+	desired := v1.ObjectMeta{
+		Labels: map[string]string{
+			GeneratedByLabel: GeneratedByLabelValue,
+			RevisionLabel:    "new",
+		},
+		Annotations: map[string]string{ChecksumAnnotation: "abc"},
+	}
+
+	testCases := []struct {
+		name     string
+		live     v1.ObjectMeta
+		expected bool
+	}{
+		{
+			name: "only the revision differs",
+			live: v1.ObjectMeta{
+				Labels: map[string]string{
+					GeneratedByLabel: GeneratedByLabelValue,
+					RevisionLabel:    "old",
+					"external":       "kept",
+				},
+				Annotations: map[string]string{ChecksumAnnotation: "abc", "external": "kept"},
+			},
+			expected: true,
+		},
+		{
+			name: "missing label",
+			live: v1.ObjectMeta{
+				Annotations: map[string]string{ChecksumAnnotation: "abc"},
+			},
+			expected: false,
+		},
+		{
+			name: "different annotation",
+			live: v1.ObjectMeta{
+				Labels:      map[string]string{GeneratedByLabel: GeneratedByLabelValue},
+				Annotations: map[string]string{ChecksumAnnotation: "def"},
+			},
+			expected: false,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("case %d: %s", i, tc.name), func(t *testing.T) {
+			result := IsMetadataUpToDate(tc.live, desired)
+
+			if result != tc.expected {
+				t.Fatalf("expected result: %t, got: %t", tc.expected, result)
+			}
+		})
+	}
+}
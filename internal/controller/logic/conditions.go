@@ -1,11 +1,33 @@
 package logic
 
+import (
+	"k8s.io/apimachinery/pkg/api/meta"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
 const (
 	// ReadyCondition indicates the resource is ready and fully reconciled.
 	// If the Condition is False, the resource SHOULD be considered to be in the process of reconciling and not a
 	// representation of actual state.
 	ReadyCondition string = "Ready"
 
+	// ReconcilingCondition indicates the resource is being reconciled. It is only present while it is True,
+	// following the abnormal-true polarity of kstatus.
+	ReconcilingCondition string = "Reconciling"
+
+	// StalledCondition indicates the reconciliation of the resource cannot make progress until its inputs are fixed.
+	// It is only present while it is True, following the abnormal-true polarity of kstatus.
+	StalledCondition string = "Stalled"
+
+	// SourceReadyCondition indicates the Flux source of the resource is available.
+	SourceReadyCondition string = "SourceReady"
+
+	// SchemaReadyCondition indicates the KonfigurationSchema of the resource is fetched and valid.
+	SchemaReadyCondition string = "SchemaReady"
+
+	// DecryptionReadyCondition indicates the SOPS environment to decrypt the source is set up.
+	DecryptionReadyCondition string = "DecryptionReady"
+
 	// ReconciliationSucceededReason represents the fact that the reconciliation succeeded.
 	ReconciliationSucceededReason string = "ReconciliationSucceeded"
 
@@ -14,4 +36,57 @@ const (
 
 	// SetupFailedReason represents the fact that the setup failed for reconciliation.
 	SetupFailedReason string = "SetupFailed"
+
+	// ProgressingReason represents the fact that the reconciliation of a new generation is in progress.
+	ProgressingReason string = "Progressing"
+
+	// SucceededReason represents the fact that a setup step succeeded.
+	SucceededReason string = "Succeeded"
+
+	// SourceUnavailableReason represents the fact that the Flux source could not be fetched.
+	SourceUnavailableReason string = "SourceUnavailable"
+
+	// SchemaUnavailableReason represents the fact that the KonfigurationSchema could not be fetched.
+	SchemaUnavailableReason string = "SchemaUnavailable"
+
+	// InvalidSchemaReason represents the fact that the KonfigurationSchema could not be loaded.
+	InvalidSchemaReason string = "InvalidSchema"
+
+	// DecryptionFailedReason represents the fact that the SOPS environment could not be set up.
+	DecryptionFailedReason string = "DecryptionFailed"
+
+	// InvalidRolloutReason represents the fact that the waves of the rollout could not be determined.
+	InvalidRolloutReason string = "InvalidRollout"
+
+	// RolloutProgressingReason represents the fact that a new revision is being rolled out wave by wave.
+	RolloutProgressingReason string = "RolloutProgressing"
+
+	// RolloutHaltedReason represents the fact that the rollout of a new revision halted, as a wave failed its
+	// health checks.
+	RolloutHaltedReason string = "RolloutHalted"
+
+	// ApprovalPendingReason represents the fact that a new revision is rendered, but awaits approval to be applied.
+	ApprovalPendingReason string = "ApprovalPending"
+
+	// InvalidWindowsReason represents the fact that the maintenance windows could not be parsed.
+	InvalidWindowsReason string = "InvalidWindows"
+
+	// ChangeFreezeReason represents the fact that a new revision is held back by the change freeze of the operator.
+	ChangeFreezeReason string = "ChangeFreeze"
+
+	// OutsideMaintenanceWindowReason represents the fact that a new revision is held back until the maintenance
+	// windows allow applying it.
+	OutsideMaintenanceWindowReason string = "OutsideMaintenanceWindow"
 )
+
+// SetCondition adds or updates the condition of the given type. The last transition time is only updated when
+// the status of the condition changes.
+func SetCondition(conditions *[]v1.Condition, conditionType string, status v1.ConditionStatus, reason, message string, generation int64) {
+	meta.SetStatusCondition(conditions, v1.Condition{
+		Type:               conditionType,
+		Status:             status,
+		ObservedGeneration: generation,
+		Reason:             reason,
+		Message:            message,
+	})
+}
@@ -0,0 +1,48 @@
+package logic
+
+import (
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSetCondition(t *testing.T) {
+	transitionTime := v1.NewTime(time.Date(2025, 3, 12, 15, 6, 7, 0, time.UTC))
+
+	conditions := []v1.Condition{
+		{
+			Type:               ReadyCondition,
+			Status:             v1.ConditionTrue,
+			ObservedGeneration: 1,
+			LastTransitionTime: transitionTime,
+			Reason:             ReconciliationSucceededReason,
+			Message:            "Applied revision: a",
+		},
+	}
+
+	SetCondition(&conditions, ReadyCondition, v1.ConditionTrue, ReconciliationSucceededReason, "Applied revision: b", 2)
+
+	ready := meta.FindStatusCondition(conditions, ReadyCondition)
+	if !ready.LastTransitionTime.Equal(&transitionTime) {
+		t.Fatalf("transition time should be kept when the status does not change, got: %s", ready.LastTransitionTime)
+	}
+
+	if ready.Message != "Applied revision: b" || ready.ObservedGeneration != 2 {
+		t.Fatalf("message and observed generation should be updated, got: %s, %d", ready.Message, ready.ObservedGeneration)
+	}
+
+	SetCondition(&conditions, ReadyCondition, v1.ConditionFalse, ReconciliationFailedReason, "Attempted revision: c", 2)
+
+	ready = meta.FindStatusCondition(conditions, ReadyCondition)
+	if ready.LastTransitionTime.Equal(&transitionTime) {
+		t.Fatalf("transition time should be updated when the status changes")
+	}
+
+	SetCondition(&conditions, SourceReadyCondition, v1.ConditionTrue, SucceededReason, "Source artifact fetched", 2)
+
+	if len(conditions) != 2 || meta.FindStatusCondition(conditions, ReadyCondition).Reason != ReconciliationFailedReason {
+		t.Fatalf("conditions of other types should be kept, got: %v", conditions)
+	}
+}
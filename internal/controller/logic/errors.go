@@ -0,0 +1,210 @@
+package logic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/giantswarm/konfigure/v2/pkg/sopsenv"
+	apiMachineryErrors "k8s.io/apimachinery/pkg/api/errors"
+
+	konfigurev1alpha1 "github.com/giantswarm/konfigure-operator/api/v1alpha1"
+)
+
+const (
+	// MissingFileReason represents the fact that a file required to render is missing from the source.
+	MissingFileReason string = "MissingFile"
+
+	// InvalidYAMLReason represents the fact that a file of the source could not be parsed as YAML.
+	InvalidYAMLReason string = "InvalidYAML"
+
+	// TemplateErrorReason represents the fact that a template of the source could not be parsed or executed.
+	TemplateErrorReason string = "TemplateError"
+
+	// DecryptionKeyMissingReason represents the fact that none of the available keys can decrypt a SOPS file.
+	DecryptionKeyMissingReason string = "DecryptionKeyMissing"
+
+	// MACMismatchReason represents the fact that the integrity check of a SOPS file failed.
+	MACMismatchReason string = "MACMismatch"
+
+	// InvalidVariablesReason represents the fact that the variables of an iteration could not be resolved or
+	// do not match the schema.
+	InvalidVariablesReason string = "InvalidVariables"
+
+	// InvalidNameReason represents the fact that the name of the rendered manifests could not be rendered.
+	InvalidNameReason string = "InvalidName"
+
+	// TargetConflictReason represents the fact that multiple iterations render the same manifests.
+	TargetConflictReason string = "TargetConflict"
+
+	// FieldManagerConflictReason represents the fact that fields of a rendered manifest are managed by another
+	// field manager.
+	FieldManagerConflictReason string = "FieldManagerConflict"
+
+	// APIErrorReason represents the fact that a request to the Kubernetes API failed.
+	APIErrorReason string = "APIError"
+
+	// TimedOutReason represents the fact that the reconciliation did not finish within its timeout.
+	TimedOutReason string = "TimedOut"
+
+	// AbortedReason represents the fact that an iteration was not reconciled because of the failure of another
+	// iteration and the failure policy of the Konfiguration.
+	AbortedReason string = "Aborted"
+)
+
+var (
+	// Errors of the konfigure renderer carry the file in their message, e.g.
+	// failed to render template from "default/apps/app/configmap-values.yaml.template": ...
+	fileInMessagePattern = regexp.MustCompile(`(?:from|in file) "([^"]+)"|required path (\S+) does not exist`)
+
+	// yaml: line 3: mapping values are not allowed in this context
+	yamlLinePattern = regexp.MustCompile(`yaml: line (\d+):`)
+
+	// template: main:75:37: executing "main" at <.key>: map has no entry for key "key"
+	templateLinePattern = regexp.MustCompile(`template: [^:\s]+:(\d+)(?::\d+)?:`)
+)
+
+// ClassifiedError is an error classified with a reason and, if known, the file and line it originates from.
+type ClassifiedError struct {
+	// Reason classifies the error, e.g. MissingFile or OwnershipConflict.
+	Reason string
+
+	// File is the path of the file in the source the error originates from, if known.
+	File string
+
+	// Line is the line in File the error originates from, zero if unknown.
+	Line int
+
+	Err error
+}
+
+// NewClassifiedError classifies the error with the given reason.
+func NewClassifiedError(reason string, err error) *ClassifiedError {
+	return &ClassifiedError{Reason: reason, Err: err}
+}
+
+func (e *ClassifiedError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ClassifiedError) Unwrap() error {
+	return e.Err
+}
+
+// IterationError returns the error as recorded for the failed iteration.
+func (e *ClassifiedError) IterationError() *konfigurev1alpha1.IterationError {
+	return &konfigurev1alpha1.IterationError{
+		Reason:  e.Reason,
+		Message: e.Error(),
+		File:    e.File,
+		Line:    e.Line,
+	}
+}
+
+// ClassifyError classifies errors of the render, SOPS and apply paths. The fallback reason is used for errors
+// that cannot be classified. Errors from the konfigure renderer and SOPS are mostly not typed, so they are
+// classified by their messages.
+func ClassifyError(err error, fallbackReason string) *ClassifiedError {
+	if err == nil {
+		return nil
+	}
+
+	if classified, ok := err.(*ClassifiedError); ok {
+		return classified
+	}
+
+	result := &ClassifiedError{Reason: fallbackReason, Err: err}
+	message := err.Error()
+
+	var ownershipConflict *OwnershipConflictError
+	var pathError *fs.PathError
+	var apiStatus apiMachineryErrors.APIStatus
+
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		result.Reason = TimedOutReason
+	case errors.As(err, &ownershipConflict):
+		result.Reason = OwnershipConflictReason
+	case apiMachineryErrors.IsConflict(err):
+		result.Reason = FieldManagerConflictReason
+	case errors.As(err, &apiStatus):
+		result.Reason = APIErrorReason
+	case errors.Is(err, &sopsenv.NotFoundError{}):
+		result.Reason = DecryptionKeyMissingReason
+	case errors.Is(err, fs.ErrNotExist) || strings.Contains(message, "does not exist"):
+		result.Reason = MissingFileReason
+		if errors.As(err, &pathError) {
+			result.File = pathError.Path
+		}
+	case strings.Contains(message, "MAC mismatch") || strings.Contains(message, "Failed to verify data integrity"):
+		result.Reason = MACMismatchReason
+	case strings.Contains(message, "Error getting data key") || strings.Contains(message, "Failed to get the data key"):
+		result.Reason = DecryptionKeyMissingReason
+	case templateLinePattern.MatchString(message):
+		result.Reason = TemplateErrorReason
+		result.Line = matchLine(templateLinePattern, message)
+	case yamlLinePattern.MatchString(message) || strings.Contains(message, "yaml: "):
+		result.Reason = InvalidYAMLReason
+		result.Line = matchLine(yamlLinePattern, message)
+	}
+
+	if result.File == "" {
+		if match := fileInMessagePattern.FindStringSubmatch(message); match != nil {
+			result.File = match[1] + match[2]
+		}
+	}
+
+	return result
+}
+
+// SummarizeFailureReasons returns the reason for the Ready condition and a short breakdown of the failures by reason.
+// The reason of the failures is used if they share one, otherwise the generic ReconciliationFailed. Aborted iterations
+// are only a consequence of the other failures, so they do not take part in choosing the reason unless all failures
+// are aborted.
+func SummarizeFailureReasons(failures []*ClassifiedError) (string, string) {
+	counts := map[string]int{}
+	for _, failure := range failures {
+		counts[failure.Reason]++
+	}
+
+	reasons := slices.Sorted(maps.Keys(counts))
+
+	causes := slices.DeleteFunc(slices.Clone(reasons), func(reason string) bool {
+		return reason == AbortedReason
+	})
+	if len(causes) == 0 {
+		causes = reasons
+	}
+
+	breakdown := make([]string, 0, len(reasons))
+	for _, reason := range reasons {
+		breakdown = append(breakdown, fmt.Sprintf("%s: %d", reason, counts[reason]))
+	}
+
+	reason := ReconciliationFailedReason
+	if len(causes) == 1 {
+		reason = causes[0]
+	}
+
+	return reason, strings.Join(breakdown, ", ")
+}
+
+func matchLine(pattern *regexp.Regexp, message string) int {
+	match := pattern.FindStringSubmatch(message)
+	if match == nil {
+		return 0
+	}
+
+	line, err := strconv.Atoi(match[1])
+	if err != nil {
+		return 0
+	}
+
+	return line
+}
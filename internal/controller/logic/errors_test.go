@@ -0,0 +1,157 @@
+package logic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"testing"
+
+	apiMachineryErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestClassifyError(t *testing.T) {
+	configmaps := schema.GroupResource{Resource: "configmaps"}
+
+	testCases := []struct {
+		name           string
+		err            error
+		expectedReason string
+		expectedFile   string
+		expectedLine   int
+	}{
+		{
+			name:           "timed out",
+			err:            fmt.Errorf("failed to download artifact: %w", context.DeadlineExceeded),
+			expectedReason: TimedOutReason,
+		},
+		{
+			name:           "missing file",
+			err:            &fs.PathError{Op: "open", Path: "default/apps/app/configmap-values.yaml.template", Err: fs.ErrNotExist},
+			expectedReason: MissingFileReason,
+			expectedFile:   "default/apps/app/configmap-values.yaml.template",
+		},
+		{
+			name:           "missing required path",
+			err:            errors.New("required path default/apps/app does not exist"),
+			expectedReason: MissingFileReason,
+			expectedFile:   "default/apps/app",
+		},
+		{
+			name:           "yaml parse error",
+			err:            errors.New("error converting YAML to JSON: yaml: line 3: mapping values are not allowed in this context"),
+			expectedReason: InvalidYAMLReason,
+			expectedLine:   3,
+		},
+		{
+			name:           "template error",
+			err:            errors.New(`failed to render template from "default/apps/app/configmap-values.yaml.template": template: main:75:37: executing "main" at <.key>: map has no entry for key "key"`),
+			expectedReason: TemplateErrorReason,
+			expectedFile:   "default/apps/app/configmap-values.yaml.template",
+			expectedLine:   75,
+		},
+		{
+			name:           "decryption key missing",
+			err:            errors.New("Error getting data key: 0 successful groups required, got 0"),
+			expectedReason: DecryptionKeyMissingReason,
+		},
+		{
+			name:           "mac mismatch",
+			err:            errors.New(`Failed to verify data integrity. expected mac "a", got "b"`),
+			expectedReason: MACMismatchReason,
+		},
+		{
+			name:           "ownership conflict",
+			err:            &OwnershipConflictError{Kind: "configmap", Err: errors.New("label mismatch")},
+			expectedReason: OwnershipConflictReason,
+		},
+		{
+			name:           "field manager conflict",
+			err:            fmt.Errorf("desired configmap has fields managed by another field manager: %w", apiMachineryErrors.NewConflict(configmaps, "app", errors.New("conflict"))),
+			expectedReason: FieldManagerConflictReason,
+		},
+		{
+			name:           "api error",
+			err:            apiMachineryErrors.NewForbidden(configmaps, "app", errors.New("forbidden")),
+			expectedReason: APIErrorReason,
+		},
+		{
+			name:           "unknown error",
+			err:            errors.New("something went wrong"),
+			expectedReason: RenderFailedReason,
+		},
+		{
+			name:           "classified error is kept",
+			err:            NewClassifiedError(InvalidVariablesReason, errors.New("invalid variables")),
+			expectedReason: InvalidVariablesReason,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("case %d: %s", i, tc.name), func(t *testing.T) {
+			result := ClassifyError(tc.err, RenderFailedReason)
+
+			if result.Reason != tc.expectedReason || result.File != tc.expectedFile || result.Line != tc.expectedLine {
+				t.Fatalf("expected %s at %s:%d, got %s at %s:%d", tc.expectedReason, tc.expectedFile, tc.expectedLine, result.Reason, result.File, result.Line)
+			}
+
+			if result.Error() != tc.err.Error() {
+				t.Fatalf("expected the message to be kept, got: %s", result.Error())
+			}
+		})
+	}
+
+	if ClassifyError(nil, RenderFailedReason) != nil {
+		t.Fatalf("expected nil for nil error")
+	}
+}
+
+func TestSummarizeFailureReasons(t *testing.T) {
+	testCases := []struct {
+		name              string
+		failures          []*ClassifiedError
+		expectedReason    string
+		expectedBreakdown string
+	}{
+		{
+			name: "single cause",
+			failures: []*ClassifiedError{
+				NewClassifiedError(MissingFileReason, errors.New("a")),
+				NewClassifiedError(MissingFileReason, errors.New("b")),
+			},
+			expectedReason:    MissingFileReason,
+			expectedBreakdown: "MissingFile: 2",
+		},
+		{
+			name: "mixed causes",
+			failures: []*ClassifiedError{
+				NewClassifiedError(MissingFileReason, errors.New("a")),
+				NewClassifiedError(APIErrorReason, errors.New("b")),
+				NewClassifiedError(MissingFileReason, errors.New("c")),
+			},
+			expectedReason:    ReconciliationFailedReason,
+			expectedBreakdown: "APIError: 1, MissingFile: 2",
+		},
+		{
+			name: "aborted iterations do not decide the reason",
+			failures: []*ClassifiedError{
+				NewClassifiedError(TemplateErrorReason, errors.New("a")),
+				NewClassifiedError(AbortedReason, errors.New("b")),
+				NewClassifiedError(AbortedReason, errors.New("c")),
+			},
+			expectedReason:    TemplateErrorReason,
+			expectedBreakdown: "Aborted: 2, TemplateError: 1",
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("case %d: %s", i, tc.name), func(t *testing.T) {
+			reason, breakdown := SummarizeFailureReasons(tc.failures)
+
+			if reason != tc.expectedReason || breakdown != tc.expectedBreakdown {
+				t.Fatalf("expected %s (%s), got %s (%s)", tc.expectedReason, tc.expectedBreakdown, reason, breakdown)
+			}
+		})
+	}
+}
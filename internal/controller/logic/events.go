@@ -0,0 +1,22 @@
+package logic
+
+const (
+	// ManifestCreatedReason represents the fact that a rendered manifest was created.
+	ManifestCreatedReason string = "ManifestCreated"
+
+	// ManifestUpdatedReason represents the fact that the data of a rendered manifest was updated.
+	ManifestUpdatedReason string = "ManifestUpdated"
+
+	// RenderFailedReason represents the fact that rendering an iteration failed.
+	RenderFailedReason string = "RenderFailed"
+
+	// ApplyFailedReason represents the fact that applying the rendered manifests of an iteration failed.
+	ApplyFailedReason string = "ApplyFailed"
+
+	// OwnershipConflictReason represents the fact that a rendered manifest exists already and is owned by another object.
+	OwnershipConflictReason string = "OwnershipConflict"
+
+	// ReconciliationDisabledReason represents the fact that a rendered manifest was not applied, because it is
+	// disabled for reconciliation.
+	ReconciliationDisabledReason string = "ReconciliationDisabled"
+)
@@ -0,0 +1,52 @@
+package logic
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"maps"
+	"slices"
+
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// IterationFingerprint identifies the inputs of rendering an iteration. Rendering the same source revision with the
+// same schema, Konfiguration generation and resolved variables yields the same output, so equal fingerprints allow
+// skipping the render.
+func IterationFingerprint(revision, schemaDigest string, generation int64, variables map[string]string) string {
+	hash := sha256.New()
+
+	_, _ = fmt.Fprintf(hash, "revision=%q\nschema=%q\ngeneration=%d\n", revision, schemaDigest, generation)
+
+	for _, name := range slices.Sorted(maps.Keys(variables)) {
+		_, _ = fmt.Fprintf(hash, "variable=%q:%q\n", name, variables[name])
+	}
+
+	return "sha256:" + hex.EncodeToString(hash.Sum(nil))
+}
+
+// IsRenderedUnchanged tells whether a live manifest still holds the rendered output it was last written with, given
+// the checksum of its live data. Manifests disabled for reconciliation are never considered unchanged, so they are
+// reported as such. The revision label is ignored like in IsMetadataUpToDate, as manifests are not written for a new
+// revision alone.
+func IsRenderedUnchanged(meta v1.ObjectMeta, liveChecksum, expectedChecksum string, expectedLabels map[string]string) bool {
+	if expectedChecksum == "" || liveChecksum != expectedChecksum || meta.Annotations[ChecksumAnnotation] != expectedChecksum {
+		return false
+	}
+
+	if !ShouldReconcile(meta) {
+		return false
+	}
+
+	for label, value := range expectedLabels {
+		if label == RevisionLabel {
+			continue
+		}
+
+		if meta.Labels[label] != value {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,107 @@
+package logic
+
+import (
+	"fmt"
+	"testing"
+
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestIterationFingerprint(t *testing.T) {
+	variables := map[string]string{"cluster": "alpha", "region": "eu"}
+
+	fingerprint := IterationFingerprint("abc", "sha256:1", 1, variables)
+
+	if fingerprint != IterationFingerprint("abc", "sha256:1", 1, map[string]string{"region": "eu", "cluster": "alpha"}) {
+		t.Fatalf("fingerprint should not depend on the order of the variables")
+	}
+
+	changed := []string{
+		IterationFingerprint("def", "sha256:1", 1, variables),
+		IterationFingerprint("abc", "sha256:2", 1, variables),
+		IterationFingerprint("abc", "sha256:1", 2, variables),
+		IterationFingerprint("abc", "sha256:1", 1, map[string]string{"cluster": "beta", "region": "eu"}),
+		IterationFingerprint("abc", "sha256:1", 1, map[string]string{"cluster": "alpha"}),
+	}
+
+	for i, other := range changed {
+		if other == fingerprint {
+			t.Fatalf("case %d: fingerprint should change with its inputs", i)
+		}
+	}
+}
+
+func TestIsRenderedUnchanged(t *testing.T) {
+	labels := map[string]string{OwnerNameLabel: "example", RevisionLabel: "abc"}
+
+	testCases := []struct {
+		name         string
+		meta         v1.ObjectMeta
+		liveChecksum string
+		expected     bool
+	}{
+		{
+			name: "unchanged",
+			meta: v1.ObjectMeta{
+				Labels:      map[string]string{OwnerNameLabel: "example", RevisionLabel: "abc", "extra": "kept"},
+				Annotations: map[string]string{ChecksumAnnotation: "sum"},
+			},
+			liveChecksum: "sum",
+			expected:     true,
+		},
+		{
+			name: "written at an older revision",
+			meta: v1.ObjectMeta{
+				Labels:      map[string]string{OwnerNameLabel: "example", RevisionLabel: "old"},
+				Annotations: map[string]string{ChecksumAnnotation: "sum"},
+			},
+			liveChecksum: "sum",
+			expected:     true,
+		},
+		{
+			name: "data drifted",
+			meta: v1.ObjectMeta{
+				Labels:      map[string]string{OwnerNameLabel: "example", RevisionLabel: "abc"},
+				Annotations: map[string]string{ChecksumAnnotation: "sum"},
+			},
+			liveChecksum: "other",
+			expected:     false,
+		},
+		{
+			name: "checksum annotation removed",
+			meta: v1.ObjectMeta{
+				Labels: map[string]string{OwnerNameLabel: "example", RevisionLabel: "abc"},
+			},
+			liveChecksum: "sum",
+			expected:     false,
+		},
+		{
+			name: "ownership label changed",
+			meta: v1.ObjectMeta{
+				Labels:      map[string]string{OwnerNameLabel: "other", RevisionLabel: "abc"},
+				Annotations: map[string]string{ChecksumAnnotation: "sum"},
+			},
+			liveChecksum: "sum",
+			expected:     false,
+		},
+		{
+			name: "disabled for reconciliation",
+			meta: v1.ObjectMeta{
+				Labels:      map[string]string{OwnerNameLabel: "example", RevisionLabel: "abc", ReconcileLabel: DisabledValue},
+				Annotations: map[string]string{ChecksumAnnotation: "sum"},
+			},
+			liveChecksum: "sum",
+			expected:     false,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("case %d: %s", i, tc.name), func(t *testing.T) {
+			result := IsRenderedUnchanged(tc.meta, tc.liveChecksum, "sum", labels)
+
+			if result != tc.expected {
+				t.Fatalf("expected %t, got %t", tc.expected, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package logic
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	konfigurev1alpha1 "github.com/giantswarm/konfigure-operator/api/v1alpha1"
+)
+
+// WorkloadHealth tells whether the workload of the given kind finished rolling out, and why not otherwise.
+// Deployments must have all their replicas updated and available, HelmReleases must have handled the latest rollout
+// trigger and be ready, and App CRs must be deployed.
+func WorkloadHealth(kind konfigurev1alpha1.RolloutTriggerKind, workload *unstructured.Unstructured) (bool, string) {
+	switch kind {
+	case konfigurev1alpha1.RolloutTriggerKindDeployment:
+		observedGeneration, _, _ := unstructured.NestedInt64(workload.Object, "status", "observedGeneration")
+		if observedGeneration < workload.GetGeneration() {
+			return false, "latest generation not observed yet"
+		}
+
+		replicas, found, _ := unstructured.NestedInt64(workload.Object, "spec", "replicas")
+		if !found {
+			replicas = 1
+		}
+
+		updated, _, _ := unstructured.NestedInt64(workload.Object, "status", "updatedReplicas")
+		if updated < replicas {
+			return false, fmt.Sprintf("%d of %d replicas updated", updated, replicas)
+		}
+
+		available, _, _ := unstructured.NestedInt64(workload.Object, "status", "availableReplicas")
+		if available < replicas {
+			return false, fmt.Sprintf("%d of %d replicas available", available, replicas)
+		}
+
+		return true, ""
+	case konfigurev1alpha1.RolloutTriggerKindHelmRelease:
+		observedGeneration, _, _ := unstructured.NestedInt64(workload.Object, "status", "observedGeneration")
+		if observedGeneration < workload.GetGeneration() {
+			return false, "latest generation not observed yet"
+		}
+
+		// Until Flux handled the trigger, the Ready condition is still the one of the previous revision.
+		if requestedAt := workload.GetAnnotations()[FluxRequestedAtAnnotation]; requestedAt != "" {
+			handledAt, _, _ := unstructured.NestedString(workload.Object, "status", "lastHandledReconcileAt")
+			if handledAt != requestedAt {
+				return false, "rollout trigger not handled yet"
+			}
+		}
+
+		conditions, _, _ := unstructured.NestedSlice(workload.Object, "status", "conditions")
+		for _, condition := range conditions {
+			condition, ok := condition.(map[string]any)
+			if !ok || condition["type"] != ReadyCondition {
+				continue
+			}
+
+			if condition["status"] == "True" {
+				return true, ""
+			}
+
+			return false, fmt.Sprintf("not ready: %v", condition["message"])
+		}
+
+		return false, "not ready yet"
+	case konfigurev1alpha1.RolloutTriggerKindApp:
+		status, _, _ := unstructured.NestedString(workload.Object, "status", "release", "status")
+		if status == "deployed" {
+			return true, ""
+		}
+
+		reason, _, _ := unstructured.NestedString(workload.Object, "status", "release", "reason")
+		if reason != "" {
+			return false, fmt.Sprintf("release status %q: %s", status, reason)
+		}
+
+		return false, fmt.Sprintf("release status %q", status)
+	default:
+		return false, fmt.Sprintf("unsupported health check kind: %s", kind)
+	}
+}
@@ -0,0 +1,128 @@
+package logic
+
+import (
+	"fmt"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	konfigurev1alpha1 "github.com/giantswarm/konfigure-operator/api/v1alpha1"
+)
+
+func TestWorkloadHealth(t *testing.T) {
+	testCases := []struct {
+		name            string
+		kind            konfigurev1alpha1.RolloutTriggerKind
+		object          map[string]any
+		expected        bool
+		expectedMessage string
+	}{
+		{
+			name: "deployment rolled out",
+			kind: konfigurev1alpha1.RolloutTriggerKindDeployment,
+			object: map[string]any{
+				"metadata": map[string]any{"generation": int64(2)},
+				"spec":     map[string]any{"replicas": int64(2)},
+				"status":   map[string]any{"observedGeneration": int64(2), "updatedReplicas": int64(2), "availableReplicas": int64(2)},
+			},
+			expected: true,
+		},
+		{
+			name: "deployment rolling out",
+			kind: konfigurev1alpha1.RolloutTriggerKindDeployment,
+			object: map[string]any{
+				"metadata": map[string]any{"generation": int64(2)},
+				"spec":     map[string]any{"replicas": int64(3)},
+				"status":   map[string]any{"observedGeneration": int64(2), "updatedReplicas": int64(3), "availableReplicas": int64(1)},
+			},
+			expectedMessage: "1 of 3 replicas available",
+		},
+		{
+			name: "deployment generation not observed",
+			kind: konfigurev1alpha1.RolloutTriggerKindDeployment,
+			object: map[string]any{
+				"metadata": map[string]any{"generation": int64(3)},
+				"status":   map[string]any{"observedGeneration": int64(2), "updatedReplicas": int64(1), "availableReplicas": int64(1)},
+			},
+			expectedMessage: "latest generation not observed yet",
+		},
+		{
+			name: "helm release ready",
+			kind: konfigurev1alpha1.RolloutTriggerKindHelmRelease,
+			object: map[string]any{
+				"metadata": map[string]any{"generation": int64(2), "annotations": map[string]any{FluxRequestedAtAnnotation: "2025-03-12T10:00:00Z"}},
+				"status": map[string]any{
+					"observedGeneration":     int64(2),
+					"lastHandledReconcileAt": "2025-03-12T10:00:00Z",
+					"conditions":             []any{map[string]any{"type": "Ready", "status": "True"}},
+				},
+			},
+			expected: true,
+		},
+		{
+			name: "helm release generation not observed",
+			kind: konfigurev1alpha1.RolloutTriggerKindHelmRelease,
+			object: map[string]any{
+				"metadata": map[string]any{"generation": int64(3)},
+				"status": map[string]any{
+					"observedGeneration": int64(2),
+					"conditions":         []any{map[string]any{"type": "Ready", "status": "True"}},
+				},
+			},
+			expectedMessage: "latest generation not observed yet",
+		},
+		{
+			name: "helm release trigger not handled",
+			kind: konfigurev1alpha1.RolloutTriggerKindHelmRelease,
+			object: map[string]any{
+				"metadata": map[string]any{"generation": int64(2), "annotations": map[string]any{FluxRequestedAtAnnotation: "2025-03-12T10:00:00Z"}},
+				"status": map[string]any{
+					"observedGeneration":     int64(2),
+					"lastHandledReconcileAt": "2025-03-11T10:00:00Z",
+					"conditions":             []any{map[string]any{"type": "Ready", "status": "True"}},
+				},
+			},
+			expectedMessage: "rollout trigger not handled yet",
+		},
+		{
+			name: "helm release failed",
+			kind: konfigurev1alpha1.RolloutTriggerKindHelmRelease,
+			object: map[string]any{
+				"status": map[string]any{"conditions": []any{map[string]any{"type": "Ready", "status": "False", "message": "upgrade failed"}}},
+			},
+			expectedMessage: "not ready: upgrade failed",
+		},
+		{
+			name:            "helm release without conditions",
+			kind:            konfigurev1alpha1.RolloutTriggerKindHelmRelease,
+			object:          map[string]any{},
+			expectedMessage: "not ready yet",
+		},
+		{
+			name: "app deployed",
+			kind: konfigurev1alpha1.RolloutTriggerKindApp,
+			object: map[string]any{
+				"status": map[string]any{"release": map[string]any{"status": "deployed"}},
+			},
+			expected: true,
+		},
+		{
+			name: "app failed",
+			kind: konfigurev1alpha1.RolloutTriggerKindApp,
+			object: map[string]any{
+				"status": map[string]any{"release": map[string]any{"status": "failed", "reason": "invalid values"}},
+			},
+			expectedMessage: `release status "failed": invalid values`,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("case %d: %s", i, tc.name), func(t *testing.T) {
+			healthy, message := WorkloadHealth(tc.kind, &unstructured.Unstructured{Object: tc.object})
+
+			if healthy != tc.expected || message != tc.expectedMessage {
+				t.Fatalf("expected healthy %t with %q, got %t with %q", tc.expected, tc.expectedMessage, healthy, message)
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package logic
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+
+	konfigurev1alpha1 "github.com/giantswarm/konfigure-operator/api/v1alpha1"
+)
+
+// SchemaDigest returns the SHA-256 digest of the given schema content.
+func SchemaDigest(content []byte) string {
+	sum := sha256.Sum256(content)
+
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// CountIterations fills the counters of the run from the statuses of the reconciled iterations. Failed iterations
+// keep the outcome of their last successful reconciliation, so they are only counted as failed. Pending iterations
+// were not reconciled, so they are only counted in the total.
+func CountIterations(run *konfigurev1alpha1.ReconciliationRun, iterations []konfigurev1alpha1.IterationStatus) {
+	run.Total = len(iterations)
+
+	for _, iteration := range iterations {
+		switch iteration.Result {
+		case konfigurev1alpha1.IterationResultFailed:
+			run.Failed++
+			continue
+		case konfigurev1alpha1.IterationResultPending:
+			continue
+		case konfigurev1alpha1.IterationResultDisabled:
+			run.Disabled++
+		}
+
+		if iteration.RenderSkipped {
+			run.Skipped++
+		}
+
+		switch iteration.Outcome {
+		case konfigurev1alpha1.ApplyOutcomeCreated:
+			run.Created++
+		case konfigurev1alpha1.ApplyOutcomeUpdated:
+			run.Updated++
+		}
+	}
+}
+
+// RecordRun returns the history with the given run prepended, keeping at most limit runs.
+func RecordRun(history []konfigurev1alpha1.ReconciliationRun, run konfigurev1alpha1.ReconciliationRun, limit int) []konfigurev1alpha1.ReconciliationRun {
+	if limit <= 0 {
+		return nil
+	}
+
+	history = append([]konfigurev1alpha1.ReconciliationRun{run}, history...)
+	if len(history) > limit {
+		history = history[:limit]
+	}
+
+	return history
+}
@@ -0,0 +1,82 @@
+package logic
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	konfigurev1alpha1 "github.com/giantswarm/konfigure-operator/api/v1alpha1"
+)
+
+func TestSchemaDigest(t *testing.T) {
+	result := SchemaDigest([]byte("variables: []\n"))
+
+	if !strings.HasPrefix(result, "sha256:") || len(result) != len("sha256:")+64 {
+		t.Fatalf("expected a sha256 digest, got %s", result)
+	}
+
+	if result == SchemaDigest([]byte("variables: [a]\n")) {
+		t.Fatalf("expected different schemas to have different digests")
+	}
+}
+
+func TestCountIterations(t *testing.T) {
+	run := konfigurev1alpha1.ReconciliationRun{}
+
+	CountIterations(&run, []konfigurev1alpha1.IterationStatus{
+		{Name: "a", Result: konfigurev1alpha1.IterationResultSucceeded, Outcome: konfigurev1alpha1.ApplyOutcomeCreated},
+		{Name: "b", Result: konfigurev1alpha1.IterationResultSucceeded, Outcome: konfigurev1alpha1.ApplyOutcomeUpdated},
+		{Name: "c", Result: konfigurev1alpha1.IterationResultSucceeded, Outcome: konfigurev1alpha1.ApplyOutcomeUnchanged},
+		{Name: "d", Result: konfigurev1alpha1.IterationResultFailed, Outcome: konfigurev1alpha1.ApplyOutcomeUpdated},
+		{Name: "e", Result: konfigurev1alpha1.IterationResultDisabled, Outcome: konfigurev1alpha1.ApplyOutcomeUpdated},
+		{Name: "f", Result: konfigurev1alpha1.IterationResultSucceeded, Outcome: konfigurev1alpha1.ApplyOutcomeUnchanged, RenderSkipped: true},
+		{Name: "g", Result: konfigurev1alpha1.IterationResultPending, Outcome: konfigurev1alpha1.ApplyOutcomeUpdated},
+	})
+
+	expected := konfigurev1alpha1.ReconciliationRun{Total: 7, Created: 1, Updated: 2, Failed: 1, Disabled: 1, Skipped: 1}
+	if !reflect.DeepEqual(run, expected) {
+		t.Fatalf("expected %+v, got %+v", expected, run)
+	}
+}
+
+func TestRecordRun(t *testing.T) {
+	history := []konfigurev1alpha1.ReconciliationRun{{Revision: "b"}, {Revision: "a"}}
+
+	testCases := []struct {
+		name     string
+		limit    int
+		expected []string
+	}{
+		{
+			name:     "prepends the newest run",
+			limit:    10,
+			expected: []string{"c", "b", "a"},
+		},
+		{
+			name:     "drops the oldest runs beyond the limit",
+			limit:    2,
+			expected: []string{"c", "b"},
+		},
+		{
+			name:     "keeps no history with zero limit",
+			limit:    0,
+			expected: nil,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("case %d: %s", i, tc.name), func(t *testing.T) {
+			result := RecordRun(history, konfigurev1alpha1.ReconciliationRun{Revision: "c"}, tc.limit)
+
+			var revisions []string
+			for _, run := range result {
+				revisions = append(revisions, run.Revision)
+			}
+
+			if !reflect.DeepEqual(revisions, tc.expected) {
+				t.Fatalf("expected %v, got %v", tc.expected, revisions)
+			}
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package logic
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+const (
+	ImmutableLabel          = KonfigureOperatorPrefix + "/immutable"
+	BaseNameAnnotation      = KonfigureOperatorPrefix + "/base-name"
+	ImmutableLabelValue     = "true"
+	immutableHashLength     = 10
+	immutableNameHashSuffix = immutableHashLength + 1
+)
+
+// ContentHash returns a hash of the given data that is stable across map orderings.
+func ContentHash(data map[string][]byte) string {
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	hash := sha256.New()
+	for _, key := range keys {
+		hash.Write([]byte(key))
+		hash.Write([]byte{0})
+		hash.Write(data[key])
+		hash.Write([]byte{0})
+	}
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// ConfigMapContentHash returns the ContentHash of the given config map data.
+func ConfigMapContentHash(data map[string]string) string {
+	converted := make(map[string][]byte, len(data))
+	for key, value := range data {
+		converted[key] = []byte(value)
+	}
+
+	return ContentHash(converted)
+}
+
+// ImmutableName appends the shortened hash to the base name, cutting the base name if needed to fit the given
+// maximum length, that is the maximum length of the names configured by the naming options.
+func ImmutableName(base, hash string, maxLength int) string {
+	if len(base) > maxLength-immutableNameHashSuffix {
+		base = strings.TrimRight(base[:maxLength-immutableNameHashSuffix], "-.")
+	}
+
+	return base + "-" + hash[:immutableHashLength]
+}
+
+// ImmutableConfigMap returns a copy of the rendered config map turned into an immutable, content-hashed one.
+func ImmutableConfigMap(configmap *corev1.ConfigMap, maxLength int) *corev1.ConfigMap {
+	immutable := configmap.DeepCopy()
+
+	markImmutable(&immutable.ObjectMeta, ConfigMapContentHash(immutable.Data), maxLength)
+	immutable.Immutable = ptr.To(true)
+
+	return immutable
+}
+
+// ImmutableSecret returns a copy of the rendered secret turned into an immutable, content-hashed one.
+func ImmutableSecret(secret *corev1.Secret, maxLength int) *corev1.Secret {
+	immutable := secret.DeepCopy()
+
+	markImmutable(&immutable.ObjectMeta, ContentHash(immutable.Data), maxLength)
+	immutable.Immutable = ptr.To(true)
+
+	return immutable
+}
+
+// SelectPrunableGenerations returns the previous generations of an immutable manifest that exceed the number of
+// generations to retain, oldest last. The current generation and generations disabled for reconciliation are kept.
+func SelectPrunableGenerations(generations []v1.ObjectMeta, current string, retain int) []v1.ObjectMeta {
+	var previous []v1.ObjectMeta
+	for _, generation := range generations {
+		if generation.Name == current || !ShouldReconcile(generation) {
+			continue
+		}
+
+		previous = append(previous, generation)
+	}
+
+	// Newest first, fall back to name to be deterministic for objects created within the same second.
+	slices.SortFunc(previous, func(a, b v1.ObjectMeta) int {
+		if c := b.CreationTimestamp.Compare(a.CreationTimestamp.Time); c != 0 {
+			return c
+		}
+
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	if len(previous) <= retain {
+		return nil
+	}
+
+	return previous[retain:]
+}
+
+func markImmutable(meta *v1.ObjectMeta, hash string, maxLength int) {
+	if meta.Labels == nil {
+		meta.Labels = map[string]string{}
+	}
+
+	if meta.Annotations == nil {
+		meta.Annotations = map[string]string{}
+	}
+
+	meta.Labels[ImmutableLabel] = ImmutableLabelValue
+	meta.Annotations[BaseNameAnnotation] = meta.Name
+
+	meta.Name = ImmutableName(meta.Name, hash, maxLength)
+}
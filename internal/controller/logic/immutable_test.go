@@ -0,0 +1,192 @@
+package logic
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestContentHash(t *testing.T) {
+	a := ContentHash(map[string][]byte{"a": []byte("1"), "b": []byte("2")})
+	b := ContentHash(map[string][]byte{"b": []byte("2"), "a": []byte("1")})
+
+	if a != b {
+		t.Fatalf("hash should not depend on map ordering, got: %s and %s", a, b)
+	}
+
+	// Keys and values are separated, so moving a character from the key to the value changes the hash.
+	c := ContentHash(map[string][]byte{"ab": []byte("1")})
+	d := ContentHash(map[string][]byte{"a": []byte("b1")})
+
+	if c == d {
+		t.Fatalf("hash should differ for different key and value boundaries, got: %s", c)
+	}
+
+	if ConfigMapContentHash(map[string]string{"a": "1", "b": "2"}) != a {
+		t.Fatalf("config map hash should match the hash of the same data as bytes")
+	}
+}
+
+func TestImmutableName(t *testing.T) {
+	hash := strings.Repeat("0123456789", 7)
+
+	testCases := []struct {
+		name      string
+		base      string
+		maxLength int
+		expected  string
+	}{
+		{
+			name:      "short base name",
+			base:      "app-operator",
+			maxLength: 253,
+			expected:  "app-operator-0123456789",
+		},
+		{
+			name:      "base name at the limit",
+			base:      strings.Repeat("a", 242),
+			maxLength: 253,
+			expected:  strings.Repeat("a", 242) + "-0123456789",
+		},
+		{
+			name:      "base name over the limit is cut",
+			base:      strings.Repeat("a", 250),
+			maxLength: 253,
+			expected:  strings.Repeat("a", 242) + "-0123456789",
+		},
+		{
+			name:      "separators at the cut are trimmed",
+			base:      strings.Repeat("a", 240) + "-.bbbbbb",
+			maxLength: 253,
+			expected:  strings.Repeat("a", 240) + "-0123456789",
+		},
+		{
+			name:      "base name over the configured max length is cut",
+			base:      strings.Repeat("a", 60),
+			maxLength: 63,
+			expected:  strings.Repeat("a", 52) + "-0123456789",
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("case %d: %s", i, tc.name), func(t *testing.T) {
+			result := ImmutableName(tc.base, hash, tc.maxLength)
+
+			if len(result) > tc.maxLength {
+				t.Fatalf("result exceeds max length %d: %s", tc.maxLength, result)
+			}
+
+			if result != tc.expected {
+				t.Fatalf("result does not match, expected: %s, got: %s", tc.expected, result)
+			}
+		})
+	}
+}
+
+func TestImmutableConfigMap(t *testing.T) {
+	labels := map[string]string{GeneratedByLabel: GeneratedByLabelValue}
+
+	configmap := &corev1.ConfigMap{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "app-operator",
+			Namespace: "giantswarm",
+			Labels:    labels,
+		},
+		Data: map[string]string{"configmap-values.yaml": "foo: bar\n"},
+	}
+
+	result := ImmutableConfigMap(configmap, 253)
+
+	expectedName := ImmutableName("app-operator", ConfigMapContentHash(configmap.Data), 253)
+	if result.Name != expectedName {
+		t.Fatalf("name does not match, expected: %s, got: %s", expectedName, result.Name)
+	}
+
+	if result.Immutable == nil || !*result.Immutable {
+		t.Fatalf("result should be immutable")
+	}
+
+	if result.Labels[ImmutableLabel] != ImmutableLabelValue || result.Annotations[BaseNameAnnotation] != "app-operator" {
+		t.Fatalf("result should be marked immutable with its base name, got labels: %v, annotations: %v", result.Labels, result.Annotations)
+	}
+
+	if configmap.Name != "app-operator" || configmap.Immutable != nil || len(labels) != 1 {
+		t.Fatalf("input should not be modified")
+	}
+}
+
+func TestSelectPrunableGenerations(t *testing.T) {
+	now := time.Date(2025, 3, 12, 15, 6, 7, 0, time.UTC)
+
+	generation := func(name string, age time.Duration, labels map[string]string) v1.ObjectMeta {
+		return v1.ObjectMeta{
+			Name:              name,
+			CreationTimestamp: v1.NewTime(now.Add(-age)),
+			Labels:            labels,
+		}
+	}
+
+	generations := []v1.ObjectMeta{
+		generation("app-4", 1*time.Minute, nil),
+		generation("app-1", 4*time.Minute, nil),
+		generation("app-3", 2*time.Minute, nil),
+		generation("app-2", 3*time.Minute, map[string]string{ReconcileLabel: DisabledValue}),
+		generation("app-0", 5*time.Minute, nil),
+	}
+
+	names := func(metas []v1.ObjectMeta) []string {
+		var result []string
+		for _, meta := range metas {
+			result = append(result, meta.Name)
+		}
+
+		return result
+	}
+
+	testCases := []struct {
+		name     string
+		current  string
+		retain   int
+		expected []string
+	}{
+		{
+			name:     "retain none, keep current and disabled",
+			current:  "app-4",
+			retain:   0,
+			expected: []string{"app-3", "app-1", "app-0"},
+		},
+		{
+			name:     "retain one",
+			current:  "app-4",
+			retain:   1,
+			expected: []string{"app-1", "app-0"},
+		},
+		{
+			name:     "retain more than exists",
+			current:  "app-4",
+			retain:   5,
+			expected: nil,
+		},
+		{
+			name:     "current not among generations yet",
+			current:  "app-5",
+			retain:   2,
+			expected: []string{"app-1", "app-0"},
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("case %d: %s", i, tc.name), func(t *testing.T) {
+			result := names(SelectPrunableGenerations(generations, tc.current, tc.retain))
+
+			if !reflect.DeepEqual(result, tc.expected) {
+				t.Fatalf("expected result: %v, got: %v", tc.expected, result)
+			}
+		})
+	}
+}
@@ -40,6 +40,23 @@ func GenerateOwnershipLabels(gvk schema.GroupVersionKind, meta v1.ObjectMeta, re
 	return labels
 }
 
+// OwnershipConflictError reports a rendered manifest that exists already and is owned by another object.
+type OwnershipConflictError struct {
+	// Kind of the rendered manifest, e.g. configmap or secret.
+	Kind string
+
+	// Err holds the mismatching ownership labels.
+	Err error
+}
+
+func (e *OwnershipConflictError) Error() string {
+	return fmt.Sprintf("desired %s exists already and is owned by another object: %s", e.Kind, e.Err.Error())
+}
+
+func (e *OwnershipConflictError) Unwrap() error {
+	return e.Err
+}
+
 // MatchOwnership Check all ownership labels except: api version (in case of CRD version bump)
 // and revision of course.
 func MatchOwnership(existing, desired v1.ObjectMeta) error {
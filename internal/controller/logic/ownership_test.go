@@ -0,0 +1,30 @@
+package logic
+
+import (
+	"errors"
+	"testing"
+
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestOwnershipConflictError(t *testing.T) {
+	existing := v1.ObjectMeta{Labels: map[string]string{GeneratedByLabel: GeneratedByLabelValue, OwnerNameLabel: "other"}}
+	desired := v1.ObjectMeta{Labels: map[string]string{GeneratedByLabel: GeneratedByLabelValue, OwnerNameLabel: "example-1"}}
+
+	mismatch := MatchOwnership(existing, desired)
+	if mismatch == nil {
+		t.Fatalf("expected ownership mismatch")
+	}
+
+	var err error = &OwnershipConflictError{Kind: "configmap", Err: mismatch}
+
+	expected := "desired configmap exists already and is owned by another object: " + mismatch.Error()
+	if err.Error() != expected {
+		t.Fatalf("error does not match, expected: %s, got: %s", expected, err.Error())
+	}
+
+	var conflict *OwnershipConflictError
+	if !errors.As(err, &conflict) || !errors.Is(err, mismatch) {
+		t.Fatalf("error should be detectable as ownership conflict and unwrap to the mismatch")
+	}
+}
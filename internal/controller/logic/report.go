@@ -0,0 +1,138 @@
+package logic
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	konfigurev1alpha1 "github.com/giantswarm/konfigure-operator/api/v1alpha1"
+)
+
+const (
+	ReportLabel      = KonfigureOperatorPrefix + "/report"
+	ReportLabelValue = "true"
+
+	// ReportKey holds the report as plain JSON.
+	ReportKey = "report.json"
+
+	// CompressedReportKey holds the report as gzipped JSON in the binary data, used for reports exceeding
+	// reportCompressionThreshold.
+	CompressedReportKey = ReportKey + ".gz"
+
+	reportNameSuffix           = "-report"
+	reportNameMaxLength        = 253
+	reportCompressionThreshold = 512 * 1024
+)
+
+// Report holds the full details of the last full reconciliation of a Konfiguration.
+type Report struct {
+	// Revision is the attempted revision of the source.
+	Revision string `json:"revision"`
+
+	// Generation is the reconciled generation of the Konfiguration.
+	Generation int64 `json:"generation"`
+
+	// ReconciledAt is the time the reconciliation finished.
+	ReconciledAt string `json:"reconciledAt"`
+
+	Summary konfigurev1alpha1.IterationSummary `json:"summary"`
+
+	// Iterations are sorted by name. Failed iterations keep the references of their last successful reconciliation.
+	Iterations []konfigurev1alpha1.IterationStatus `json:"iterations"`
+
+	// Disabled are the rendered manifests that were not applied, because their reconciliation is disabled.
+	Disabled []konfigurev1alpha1.DisabledIteration `json:"disabled,omitempty"`
+}
+
+// ReportName returns the name of the report config map of the Konfiguration with the given name.
+func ReportName(name string) string {
+	if len(name) > reportNameMaxLength-len(reportNameSuffix) {
+		name = strings.TrimRight(name[:reportNameMaxLength-len(reportNameSuffix)], "-.")
+	}
+
+	return name + reportNameSuffix
+}
+
+// ReportConfigMap returns the config map holding the report of the given Konfiguration. Reports exceeding
+// reportCompressionThreshold are stored compressed, to stay within the size limit of config maps.
+func ReportConfigMap(gvk schema.GroupVersionKind, meta v1.ObjectMeta, report Report) (*corev1.ConfigMap, error) {
+	encoded, err := json.Marshal(report)
+	if err != nil {
+		return nil, err
+	}
+
+	labels := GenerateOwnershipLabels(gvk, meta, report.Revision)
+	labels[ReportLabel] = ReportLabelValue
+
+	configmap := &corev1.ConfigMap{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      ReportName(meta.Name),
+			Namespace: meta.Namespace,
+			Labels:    labels,
+		},
+	}
+
+	if len(encoded) <= reportCompressionThreshold {
+		configmap.Data = map[string]string{ReportKey: string(encoded)}
+		return configmap, nil
+	}
+
+	var compressed bytes.Buffer
+	writer := gzip.NewWriter(&compressed)
+	if _, err = writer.Write(encoded); err != nil {
+		return nil, err
+	}
+	if err = writer.Close(); err != nil {
+		return nil, err
+	}
+
+	configmap.BinaryData = map[string][]byte{CompressedReportKey: compressed.Bytes()}
+
+	return configmap, nil
+}
+
+// ReportConfigMapKey returns the key the report is stored under in the given report config map.
+func ReportConfigMapKey(configmap *corev1.ConfigMap) string {
+	if _, ok := configmap.BinaryData[CompressedReportKey]; ok {
+		return CompressedReportKey
+	}
+
+	return ReportKey
+}
+
+// DecodeReport reads the report from the given report config map.
+func DecodeReport(configmap *corev1.ConfigMap) (*Report, error) {
+	var encoded []byte
+
+	if raw, ok := configmap.Data[ReportKey]; ok {
+		encoded = []byte(raw)
+	} else if compressed, ok := configmap.BinaryData[CompressedReportKey]; ok {
+		reader, err := gzip.NewReader(bytes.NewReader(compressed))
+		if err != nil {
+			return nil, err
+		}
+		defer func() {
+			_ = reader.Close()
+		}()
+
+		if encoded, err = io.ReadAll(reader); err != nil {
+			return nil, err
+		}
+	} else {
+		return nil, fmt.Errorf("report configmap %s/%s has neither %s nor %s", configmap.Namespace, configmap.Name, ReportKey, CompressedReportKey)
+	}
+
+	report := &Report{}
+	if err := json.Unmarshal(encoded, report); err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
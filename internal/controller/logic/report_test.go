@@ -0,0 +1,106 @@
+package logic
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	konfigurev1alpha1 "github.com/giantswarm/konfigure-operator/api/v1alpha1"
+)
+
+func TestReportName(t *testing.T) {
+	if result := ReportName("example"); result != "example-report" {
+		t.Fatalf("expected example-report, got %s", result)
+	}
+
+	if result := ReportName(strings.Repeat("a", 253)); len(result) != 253 || !strings.HasSuffix(result, "-report") {
+		t.Fatalf("expected a name of 253 characters ending with -report, got %s", result)
+	}
+}
+
+func TestReportConfigMap(t *testing.T) {
+	gvk := schema.GroupVersionKind{Group: "konfigure.giantswarm.io", Version: "v1alpha1", Kind: "Konfiguration"}
+	meta := v1.ObjectMeta{Name: "example", Namespace: "giantswarm"}
+
+	testCases := []struct {
+		name          string
+		lastError     string
+		expectedKey   string
+		expectedPlain bool
+	}{
+		{
+			name:          "small report is stored as plain json",
+			lastError:     "failed to render",
+			expectedKey:   ReportKey,
+			expectedPlain: true,
+		},
+		{
+			name:          "large report is compressed",
+			lastError:     strings.Repeat("failed to render ", reportCompressionThreshold/16),
+			expectedKey:   CompressedReportKey,
+			expectedPlain: false,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("case %d: %s", i, tc.name), func(t *testing.T) {
+			report := Report{
+				Revision:     "abc",
+				Generation:   2,
+				ReconciledAt: "2026-10-19T10:00:00Z",
+				Summary:      konfigurev1alpha1.IterationSummary{Total: 2, Succeeded: 1, Failed: 1},
+				Iterations: []konfigurev1alpha1.IterationStatus{
+					{Name: "a", Result: konfigurev1alpha1.IterationResultSucceeded},
+					{Name: "b", Result: konfigurev1alpha1.IterationResultFailed, LastError: &konfigurev1alpha1.IterationError{Reason: RenderFailedReason, Message: tc.lastError}},
+				},
+			}
+
+			configmap, err := ReportConfigMap(gvk, meta, report)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if configmap.Name != "example-report" || configmap.Namespace != "giantswarm" {
+				t.Fatalf("unexpected report reference: %s/%s", configmap.Namespace, configmap.Name)
+			}
+
+			if configmap.Labels[ReportLabel] != ReportLabelValue || configmap.Labels[OwnerNameLabel] != "example" {
+				t.Fatalf("unexpected labels: %v", configmap.Labels)
+			}
+
+			if key := ReportConfigMapKey(configmap); key != tc.expectedKey {
+				t.Fatalf("expected key %s, got %s", tc.expectedKey, key)
+			}
+
+			if _, plain := configmap.Data[ReportKey]; plain != tc.expectedPlain {
+				t.Fatalf("expected plain report: %t, got data keys: %v", tc.expectedPlain, configmap.Data)
+			}
+
+			decoded, err := DecodeReport(configmap)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !reflect.DeepEqual(*decoded, report) {
+				t.Fatalf("expected %+v, got %+v", report, *decoded)
+			}
+		})
+	}
+}
+
+func TestDecodeReportWithoutReport(t *testing.T) {
+	configmap, err := ReportConfigMap(schema.GroupVersionKind{}, v1.ObjectMeta{Name: "example"}, Report{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	configmap.Data = nil
+
+	if _, err = DecodeReport(configmap); err == nil {
+		t.Fatalf("expected an error for a configmap without report")
+	}
+}
@@ -0,0 +1,109 @@
+package logic
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	konfigurev1alpha1 "github.com/giantswarm/konfigure-operator/api/v1alpha1"
+)
+
+const (
+	ChecksumAnnotation = KonfigureOperatorPrefix + "/checksum"
+
+	// FluxRequestedAtAnnotation requests an immediate reconciliation from Flux controllers.
+	FluxRequestedAtAnnotation = "reconcile.fluxcd.io/requestedAt"
+)
+
+// StampChecksum sets the checksum annotation on the given rendered manifest.
+func StampChecksum(meta *v1.ObjectMeta, checksum string) {
+	if meta.Annotations == nil {
+		meta.Annotations = map[string]string{}
+	}
+
+	meta.Annotations[ChecksumAnnotation] = checksum
+}
+
+// IterationChecksum combines the checksums of the rendered manifests of an iteration. Empty checksums belong to
+// manifests that are not rendered and are left out.
+func IterationChecksum(configMapChecksum, secretChecksum string) string {
+	checksums := map[string][]byte{}
+
+	if configMapChecksum != "" {
+		checksums["ConfigMap"] = []byte(configMapChecksum)
+	}
+
+	if secretChecksum != "" {
+		checksums["Secret"] = []byte(secretChecksum)
+	}
+
+	return ContentHash(checksums)
+}
+
+// ShouldTriggerRollout tells whether the rollout triggers of an iteration should be fired. When a checksum was
+// propagated before, it is compared to the current one, so failed triggers are retried on the next reconciliation.
+// Otherwise, the triggers are only fired when the applied data changed, to avoid rolling out all workloads when
+// upgrading from a version not recording checksums.
+func ShouldTriggerRollout(previousChecksum, checksum string, changed bool) bool {
+	if previousChecksum != "" {
+		return previousChecksum != checksum
+	}
+
+	return changed
+}
+
+// RolloutTriggerGVK returns the group version kind of the workload kind to notify.
+func RolloutTriggerGVK(kind konfigurev1alpha1.RolloutTriggerKind) (schema.GroupVersionKind, error) {
+	switch kind {
+	case konfigurev1alpha1.RolloutTriggerKindDeployment:
+		return schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}, nil
+	case konfigurev1alpha1.RolloutTriggerKindHelmRelease:
+		return schema.GroupVersionKind{Group: "helm.toolkit.fluxcd.io", Version: "v2", Kind: "HelmRelease"}, nil
+	case konfigurev1alpha1.RolloutTriggerKindApp:
+		return schema.GroupVersionKind{Group: "application.giantswarm.io", Version: "v1alpha1", Kind: "App"}, nil
+	default:
+		return schema.GroupVersionKind{}, fmt.Errorf("unsupported rollout trigger kind: %s", kind)
+	}
+}
+
+// RolloutTriggerPatch returns the JSON merge patch that notifies the workload kind about changed data.
+// Deployments get the checksum on their pod template to roll out new pods, HelmReleases get a reconciliation
+// requested in addition to the checksum on their metadata and App CRs get the checksum on their metadata.
+func RolloutTriggerPatch(kind konfigurev1alpha1.RolloutTriggerKind, checksum string, requestedAt time.Time) ([]byte, error) {
+	var patch map[string]any
+
+	switch kind {
+	case konfigurev1alpha1.RolloutTriggerKindDeployment:
+		patch = map[string]any{
+			"spec": map[string]any{
+				"template": map[string]any{
+					"metadata": map[string]any{
+						"annotations": map[string]string{ChecksumAnnotation: checksum},
+					},
+				},
+			},
+		}
+	case konfigurev1alpha1.RolloutTriggerKindHelmRelease:
+		patch = map[string]any{
+			"metadata": map[string]any{
+				"annotations": map[string]string{
+					ChecksumAnnotation:        checksum,
+					FluxRequestedAtAnnotation: requestedAt.UTC().Format(time.RFC3339Nano),
+				},
+			},
+		}
+	case konfigurev1alpha1.RolloutTriggerKindApp:
+		patch = map[string]any{
+			"metadata": map[string]any{
+				"annotations": map[string]string{ChecksumAnnotation: checksum},
+			},
+		}
+	default:
+		return nil, fmt.Errorf("unsupported rollout trigger kind: %s", kind)
+	}
+
+	return json.Marshal(patch)
+}
@@ -0,0 +1,125 @@
+package logic
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	konfigurev1alpha1 "github.com/giantswarm/konfigure-operator/api/v1alpha1"
+)
+
+func TestIterationChecksum(t *testing.T) {
+	both := IterationChecksum("a", "b")
+
+	if both != IterationChecksum("a", "b") {
+		t.Fatalf("checksum should be stable")
+	}
+
+	// The kind of the manifest is part of the checksum, so swapping them changes the result.
+	if both == IterationChecksum("b", "a") {
+		t.Fatalf("checksum should differ when config map and secret checksums are swapped")
+	}
+
+	if IterationChecksum("a", "") == IterationChecksum("", "a") {
+		t.Fatalf("checksum should differ between config map only and secret only output")
+	}
+}
+
+func TestShouldTriggerRollout(t *testing.T) {
+	testCases := []struct {
+		name             string
+		previousChecksum string
+		checksum         string
+		changed          bool
+		expected         bool
+	}{
+		{
+			name:     "no previous checksum and data unchanged",
+			checksum: "a",
+			expected: false,
+		},
+		{
+			name:     "no previous checksum and data changed",
+			checksum: "a",
+			changed:  true,
+			expected: true,
+		},
+		{
+			name:             "previous checksum matches",
+			previousChecksum: "a",
+			checksum:         "a",
+			changed:          true,
+			expected:         false,
+		},
+		{
+			name:             "previous checksum differs, retry of a failed trigger",
+			previousChecksum: "a",
+			checksum:         "b",
+			expected:         true,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("case %d: %s", i, tc.name), func(t *testing.T) {
+			result := ShouldTriggerRollout(tc.previousChecksum, tc.checksum, tc.changed)
+
+			if result != tc.expected {
+				t.Fatalf("expected result: %t, got: %t", tc.expected, result)
+			}
+		})
+	}
+}
+
+func TestRolloutTriggerPatch(t *testing.T) {
+	requestedAt := time.Date(2025, 3, 12, 15, 6, 7, 0, time.UTC)
+
+	testCases := []struct {
+		name          string
+		kind          konfigurev1alpha1.RolloutTriggerKind
+		expected      string
+		expectedError string
+	}{
+		{
+			name:     "deployment pod template",
+			kind:     konfigurev1alpha1.RolloutTriggerKindDeployment,
+			expected: `{"spec":{"template":{"metadata":{"annotations":{"configuration.giantswarm.io/checksum":"abc"}}}}}`,
+		},
+		{
+			name:     "helm release requested reconciliation",
+			kind:     konfigurev1alpha1.RolloutTriggerKindHelmRelease,
+			expected: `{"metadata":{"annotations":{"configuration.giantswarm.io/checksum":"abc","reconcile.fluxcd.io/requestedAt":"2025-03-12T15:06:07Z"}}}`,
+		},
+		{
+			name:     "app metadata",
+			kind:     konfigurev1alpha1.RolloutTriggerKindApp,
+			expected: `{"metadata":{"annotations":{"configuration.giantswarm.io/checksum":"abc"}}}`,
+		},
+		{
+			name:          "unsupported kind",
+			kind:          "StatefulSet",
+			expectedError: "unsupported rollout trigger kind: StatefulSet",
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("case %d: %s", i, tc.name), func(t *testing.T) {
+			result, err := RolloutTriggerPatch(tc.kind, "abc", requestedAt)
+
+			if tc.expectedError != "" {
+				if err == nil || err.Error() != tc.expectedError {
+					t.Fatalf("expected error: %s, got: %v", tc.expectedError, err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if string(result) != tc.expected {
+				t.Fatalf("result does not match, expected: %s, got: %s", tc.expected, string(result))
+			}
+		})
+	}
+}
@@ -0,0 +1,171 @@
+package logic
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// scheduleHorizon bounds the search for the next time a schedule fires, so schedules that never fire, e.g. on
+// February 30, do not loop forever. It spans leap years.
+const scheduleHorizon = 5 * 366 * 24 * time.Hour
+
+// Schedule is a parsed cron expression of five fields: minute, hour, day of month, month and day of week. Each field
+// holds a bit per allowed value.
+type Schedule struct {
+	minute, hour, dayOfMonth, month, dayOfWeek uint64
+
+	// Following cron, a time matches either day field when both of them are restricted.
+	dayOfMonthRestricted, dayOfWeekRestricted bool
+}
+
+type scheduleField struct {
+	name     string
+	min, max int
+}
+
+var (
+	minuteField     = scheduleField{name: "minute", min: 0, max: 59}
+	hourField       = scheduleField{name: "hour", min: 0, max: 23}
+	dayOfMonthField = scheduleField{name: "day of month", min: 1, max: 31}
+	monthField      = scheduleField{name: "month", min: 1, max: 12}
+	// Both 0 and 7 are Sunday.
+	dayOfWeekField = scheduleField{name: "day of week", min: 0, max: 7}
+)
+
+// ParseSchedule parses a cron expression of five fields. Fields are `*`, values, ranges like `1-5` and steps like
+// `*/15` or `0-30/10`, separated by commas.
+func ParseSchedule(expression string) (*Schedule, error) {
+	fields := strings.Fields(expression)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("invalid schedule %q: expected 5 fields, got %d", expression, len(fields))
+	}
+
+	schedule := &Schedule{}
+
+	var err error
+	if schedule.minute, err = parseScheduleField(fields[0], minuteField); err != nil {
+		return nil, fmt.Errorf("invalid schedule %q: %w", expression, err)
+	}
+	if schedule.hour, err = parseScheduleField(fields[1], hourField); err != nil {
+		return nil, fmt.Errorf("invalid schedule %q: %w", expression, err)
+	}
+	if schedule.dayOfMonth, err = parseScheduleField(fields[2], dayOfMonthField); err != nil {
+		return nil, fmt.Errorf("invalid schedule %q: %w", expression, err)
+	}
+	if schedule.month, err = parseScheduleField(fields[3], monthField); err != nil {
+		return nil, fmt.Errorf("invalid schedule %q: %w", expression, err)
+	}
+	if schedule.dayOfWeek, err = parseScheduleField(fields[4], dayOfWeekField); err != nil {
+		return nil, fmt.Errorf("invalid schedule %q: %w", expression, err)
+	}
+
+	if schedule.dayOfWeek&(1<<7) != 0 {
+		schedule.dayOfWeek |= 1
+	}
+
+	schedule.dayOfMonthRestricted = !strings.HasPrefix(fields[2], "*")
+	schedule.dayOfWeekRestricted = !strings.HasPrefix(fields[4], "*")
+
+	return schedule, nil
+}
+
+func parseScheduleField(value string, field scheduleField) (uint64, error) {
+	var bits uint64
+
+	for _, part := range strings.Split(value, ",") {
+		rangePart, stepPart, hasStep := strings.Cut(part, "/")
+
+		step := 1
+		if hasStep {
+			var err error
+			step, err = strconv.Atoi(stepPart)
+			if err != nil || step <= 0 {
+				return 0, fmt.Errorf("invalid step %q of %s", stepPart, field.name)
+			}
+		}
+
+		start, end := field.min, field.max
+		if rangePart != "*" {
+			startPart, endPart, isRange := strings.Cut(rangePart, "-")
+
+			var err error
+			if start, err = parseScheduleValue(startPart, field); err != nil {
+				return 0, err
+			}
+
+			end = start
+			if isRange {
+				if end, err = parseScheduleValue(endPart, field); err != nil {
+					return 0, err
+				}
+			} else if hasStep {
+				end = field.max
+			}
+
+			if start > end {
+				return 0, fmt.Errorf("invalid range %q of %s", rangePart, field.name)
+			}
+		}
+
+		for v := start; v <= end; v += step {
+			bits |= 1 << v
+		}
+	}
+
+	return bits, nil
+}
+
+func parseScheduleValue(value string, field scheduleField) (int, error) {
+	v, err := strconv.Atoi(value)
+	if err != nil || v < field.min || v > field.max {
+		return 0, fmt.Errorf("invalid %s %q, expected %d-%d", field.name, value, field.min, field.max)
+	}
+
+	return v, nil
+}
+
+// Next returns the first time after the given one the schedule fires at, in the location of the given time. It returns
+// the zero time when the schedule does not fire within the next five years.
+func (s *Schedule) Next(after time.Time) time.Time {
+	location := after.Location()
+	limit := after.Add(scheduleHorizon)
+
+	t := time.Date(after.Year(), after.Month(), after.Day(), after.Hour(), after.Minute(), 0, 0, location).Add(time.Minute)
+
+	for t.Before(limit) {
+		previous := t
+
+		switch {
+		case s.month&(1<<uint(t.Month())) == 0:
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, location)
+		case !s.matchesDay(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, location)
+		case s.hour&(1<<uint(t.Hour())) == 0:
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), 0, 0, 0, location).Add(time.Hour)
+		case s.minute&(1<<uint(t.Minute())) == 0:
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+
+		// Wall clock times repeated by daylight saving time changes must not send the search back.
+		if !t.After(previous) {
+			t = previous.Add(time.Minute)
+		}
+	}
+
+	return time.Time{}
+}
+
+func (s *Schedule) matchesDay(t time.Time) bool {
+	dayOfMonth := s.dayOfMonth&(1<<uint(t.Day())) != 0
+	dayOfWeek := s.dayOfWeek&(1<<uint(t.Weekday())) != 0
+
+	if s.dayOfMonthRestricted && s.dayOfWeekRestricted {
+		return dayOfMonth || dayOfWeek
+	}
+
+	return dayOfMonth && dayOfWeek
+}
@@ -0,0 +1,159 @@
+package logic
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestParseSchedule(t *testing.T) {
+	testCases := []struct {
+		name          string
+		expression    string
+		expectedError bool
+	}{
+		{
+			name:       "wildcards",
+			expression: "* * * * *",
+		},
+		{
+			name:       "values, ranges, steps and lists",
+			expression: "*/15 9-17 1,15 1-12/3 1-5",
+		},
+		{
+			name:       "sunday as 7",
+			expression: "0 0 * * 7",
+		},
+		{
+			name:          "missing field",
+			expression:    "0 9 * *",
+			expectedError: true,
+		},
+		{
+			name:          "value out of range",
+			expression:    "60 9 * * *",
+			expectedError: true,
+		},
+		{
+			name:          "reversed range",
+			expression:    "0 17-9 * * *",
+			expectedError: true,
+		},
+		{
+			name:          "invalid step",
+			expression:    "*/0 * * * *",
+			expectedError: true,
+		},
+		{
+			name:          "names are not supported",
+			expression:    "0 9 * * MON",
+			expectedError: true,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("case %d: %s", i, tc.name), func(t *testing.T) {
+			_, err := ParseSchedule(tc.expression)
+
+			if tc.expectedError && err == nil {
+				t.Fatalf("expected error, got none")
+			}
+
+			if !tc.expectedError && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestScheduleNext(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatalf("unexpected error on loading location: %v", err)
+	}
+
+	// A Wednesday.
+	after := time.Date(2025, 3, 12, 10, 30, 15, 0, time.UTC)
+
+	testCases := []struct {
+		name       string
+		expression string
+		after      time.Time
+		expected   time.Time
+	}{
+		{
+			name:       "next minute",
+			expression: "* * * * *",
+			after:      after,
+			expected:   time.Date(2025, 3, 12, 10, 31, 0, 0, time.UTC),
+		},
+		{
+			name:       "strictly after",
+			expression: "30 10 * * *",
+			after:      time.Date(2025, 3, 12, 10, 30, 0, 0, time.UTC),
+			expected:   time.Date(2025, 3, 13, 10, 30, 0, 0, time.UTC),
+		},
+		{
+			name:       "steps",
+			expression: "*/20 * * * *",
+			after:      after,
+			expected:   time.Date(2025, 3, 12, 10, 40, 0, 0, time.UTC),
+		},
+		{
+			name:       "next weekday",
+			expression: "0 9 * * 1-5",
+			after:      time.Date(2025, 3, 14, 10, 0, 0, 0, time.UTC),
+			expected:   time.Date(2025, 3, 17, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			name:       "either restricted day",
+			expression: "0 0 1 * 0",
+			after:      after,
+			expected:   time.Date(2025, 3, 16, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:       "next year",
+			expression: "0 0 1 1 *",
+			after:      after,
+			expected:   time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:       "leap day",
+			expression: "0 0 29 2 *",
+			after:      after,
+			expected:   time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:       "never",
+			expression: "0 0 30 2 *",
+			after:      after,
+		},
+		{
+			name:       "time zone",
+			expression: "0 9 * * *",
+			after:      after.In(berlin),
+			expected:   time.Date(2025, 3, 13, 9, 0, 0, 0, berlin),
+		},
+		{
+			name:       "skipped by daylight saving time",
+			expression: "30 2 * * *",
+			after:      time.Date(2025, 3, 30, 0, 0, 0, 0, berlin),
+			expected:   time.Date(2025, 3, 31, 2, 30, 0, 0, berlin),
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("case %d: %s", i, tc.name), func(t *testing.T) {
+			schedule, err := ParseSchedule(tc.expression)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			result := schedule.Next(tc.after)
+
+			if !result.Equal(tc.expected) {
+				t.Fatalf("expected %s, got %s", tc.expected, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package logic
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// GitRepositoryGVKs are the served versions of Flux GitRepositories, in order of preference.
+var GitRepositoryGVKs = []schema.GroupVersionKind{
+	{Group: "source.toolkit.fluxcd.io", Version: "v1", Kind: "GitRepository"},
+	{Group: "source.toolkit.fluxcd.io", Version: "v1beta2", Kind: "GitRepository"},
+}
+
+// ArtifactURL returns the URL of the latest artifact of the given Flux GitRepository. When there is no artifact yet,
+// the message of its Ready condition tells why.
+func ArtifactURL(gitRepository *unstructured.Unstructured) (string, error) {
+	url, _, err := unstructured.NestedString(gitRepository.Object, "status", "artifact", "url")
+	if err != nil {
+		return "", err
+	}
+
+	if url != "" {
+		return url, nil
+	}
+
+	conditions, _, err := unstructured.NestedSlice(gitRepository.Object, "status", "conditions")
+	if err != nil {
+		return "", err
+	}
+
+	for _, condition := range conditions {
+		fields, ok := condition.(map[string]any)
+		if !ok || fields["type"] != "Ready" || fields["status"] == string(v1.ConditionTrue) {
+			continue
+		}
+
+		if message, ok := fields["message"].(string); ok && message != "" {
+			return "", fmt.Errorf("GitRepository %s/%s is not ready: %s", gitRepository.GetNamespace(), gitRepository.GetName(), message)
+		}
+	}
+
+	return "", errors.New("got empty artifact URL from GitRepository status")
+}
+
+// ArtifactRevision returns the revision of the artifact at the given URL. Flux names artifacts after the commit they
+// were built from, e.g. <sha>.tar.gz.
+func ArtifactRevision(url string) string {
+	name := path.Base(url)
+
+	revision, _, _ := strings.Cut(name, ".")
+
+	return revision
+}
@@ -0,0 +1,76 @@
+package logic
+
+import (
+	"fmt"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestArtifactURL(t *testing.T) {
+	testCases := []struct {
+		name          string
+		status        map[string]any
+		expected      string
+		expectedError string
+	}{
+		{
+			name: "artifact present",
+			status: map[string]any{
+				"artifact": map[string]any{"url": "http://source-controller/gitrepository/flux-giantswarm/config/abc.tar.gz"},
+			},
+			expected: "http://source-controller/gitrepository/flux-giantswarm/config/abc.tar.gz",
+		},
+		{
+			name: "not ready without artifact",
+			status: map[string]any{
+				"conditions": []any{
+					map[string]any{"type": "Reconciling", "status": "True", "message": "cloning"},
+					map[string]any{"type": "Ready", "status": "False", "message": "authentication required"},
+				},
+			},
+			expectedError: "GitRepository flux-giantswarm/config is not ready: authentication required",
+		},
+		{
+			name:          "no status",
+			expectedError: "got empty artifact URL from GitRepository status",
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("case %d: %s", i, tc.name), func(t *testing.T) {
+			gitRepository := &unstructured.Unstructured{Object: map[string]any{}}
+			gitRepository.SetGroupVersionKind(GitRepositoryGVKs[0])
+			gitRepository.SetName("config")
+			gitRepository.SetNamespace("flux-giantswarm")
+			if tc.status != nil {
+				gitRepository.Object["status"] = tc.status
+			}
+
+			url, err := ArtifactURL(gitRepository)
+
+			if tc.expectedError != "" {
+				if err == nil || err.Error() != tc.expectedError {
+					t.Fatalf("expected error %q, got: %v", tc.expectedError, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if url != tc.expected {
+				t.Fatalf("expected %s, got %s", tc.expected, url)
+			}
+		})
+	}
+}
+
+func TestArtifactRevision(t *testing.T) {
+	revision := ArtifactRevision("http://source-controller/gitrepository/flux-giantswarm/config/c8f73a3b.tar.gz")
+
+	if revision != "c8f73a3b" {
+		t.Fatalf("expected c8f73a3b, got %s", revision)
+	}
+}
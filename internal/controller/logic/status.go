@@ -0,0 +1,25 @@
+package logic
+
+import (
+	konfigurev1alpha1 "github.com/giantswarm/konfigure-operator/api/v1alpha1"
+)
+
+// SummarizeIterations counts the iteration statuses by their result.
+func SummarizeIterations(iterations []konfigurev1alpha1.IterationStatus) konfigurev1alpha1.IterationSummary {
+	summary := konfigurev1alpha1.IterationSummary{Total: len(iterations)}
+
+	for _, iteration := range iterations {
+		switch iteration.Result {
+		case konfigurev1alpha1.IterationResultSucceeded:
+			summary.Succeeded++
+		case konfigurev1alpha1.IterationResultFailed:
+			summary.Failed++
+		case konfigurev1alpha1.IterationResultDisabled:
+			summary.Disabled++
+		case konfigurev1alpha1.IterationResultPending:
+			summary.Pending++
+		}
+	}
+
+	return summary
+}
@@ -0,0 +1,43 @@
+package logic
+
+import (
+	"fmt"
+	"testing"
+
+	konfigurev1alpha1 "github.com/giantswarm/konfigure-operator/api/v1alpha1"
+)
+
+func TestSummarizeIterations(t *testing.T) {
+	testCases := []struct {
+		name       string
+		iterations []konfigurev1alpha1.IterationStatus
+		expected   konfigurev1alpha1.IterationSummary
+	}{
+		{
+			name:       "no iterations",
+			iterations: nil,
+			expected:   konfigurev1alpha1.IterationSummary{},
+		},
+		{
+			name: "mixed results",
+			iterations: []konfigurev1alpha1.IterationStatus{
+				{Name: "a", Result: konfigurev1alpha1.IterationResultSucceeded},
+				{Name: "b", Result: konfigurev1alpha1.IterationResultFailed},
+				{Name: "c", Result: konfigurev1alpha1.IterationResultDisabled},
+				{Name: "d", Result: konfigurev1alpha1.IterationResultSucceeded},
+				{Name: "e", Result: konfigurev1alpha1.IterationResultPending},
+			},
+			expected: konfigurev1alpha1.IterationSummary{Total: 5, Succeeded: 2, Failed: 1, Disabled: 1, Pending: 1},
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("case %d: %s", i, tc.name), func(t *testing.T) {
+			result := SummarizeIterations(tc.iterations)
+
+			if result != tc.expected {
+				t.Fatalf("expected %+v, got %+v", tc.expected, result)
+			}
+		})
+	}
+}
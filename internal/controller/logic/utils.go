@@ -2,16 +2,13 @@ package logic
 
 import "strings"
 
-func FilterExternalFromMap(existing map[string]string) map[string]string {
-	externals := make(map[string]string)
-
-	for key, value := range existing {
-		if strings.HasPrefix(key, KonfigureOperatorPrefix) {
-			continue
+// IsEmptySecretData tells whether the given secret data holds no content other than whitespace.
+func IsEmptySecretData(data map[string][]byte) bool {
+	for _, value := range data {
+		if strings.TrimSpace(string(value)) != "" {
+			return false
 		}
-
-		externals[key] = value
 	}
 
-	return externals
+	return true
 }
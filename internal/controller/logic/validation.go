@@ -0,0 +1,46 @@
+package logic
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	konfigureModel "github.com/giantswarm/konfigure/v2/pkg/model"
+)
+
+// ValidateVariables checks the variables of a single iteration against the variables declared in the schema,
+// so that typos are reported precisely instead of surfacing as an opaque error from the renderer.
+// Built-in variables are always injected, thus they are not considered unknown even if the schema does not declare them.
+func ValidateVariables(declared []konfigureModel.Variable, variables map[string]string) error {
+	var messages []string
+
+	declaredNames := make([]string, 0, len(declared))
+	for _, variable := range declared {
+		declaredNames = append(declaredNames, variable.Name)
+
+		if _, ok := variables[variable.Name]; !ok && variable.Required {
+			messages = append(messages, fmt.Sprintf("required variable \"%s\" is not set", variable.Name))
+		}
+	}
+
+	var unknownNames []string
+	for name := range variables {
+		if strings.HasPrefix(name, BuiltinVariablePrefix) || slices.Contains(declaredNames, name) {
+			continue
+		}
+
+		unknownNames = append(unknownNames, name)
+	}
+	slices.Sort(unknownNames)
+
+	for _, name := range unknownNames {
+		messages = append(messages, fmt.Sprintf("variable \"%s\" is not declared in the schema", name))
+	}
+
+	if len(messages) > 0 {
+		return errors.New("invalid variables: " + strings.Join(messages, ", "))
+	}
+
+	return nil
+}
@@ -0,0 +1,104 @@
+package logic
+
+import (
+	"fmt"
+	"testing"
+
+	konfigureModel "github.com/giantswarm/konfigure/v2/pkg/model"
+)
+
+func TestValidateVariables(t *testing.T) {
+	declared := []konfigureModel.Variable{
+		{Name: "installation", Required: true},
+		{Name: "app", Required: true},
+		{Name: "stage", Required: false, Default: "production"},
+	}
+
+	testCases := []struct {
+		name          string
+		declared      []konfigureModel.Variable
+		variables     map[string]string
+		expectedError string
+	}{
+		{
+			name:      "nothing declared, nothing set",
+			declared:  nil,
+			variables: nil,
+		},
+		{
+			name:     "all required set, optional omitted",
+			declared: declared,
+			variables: map[string]string{
+				"installation": "golem",
+				"app":          "app-operator",
+			},
+		},
+		{
+			name:     "empty value satisfies required",
+			declared: declared,
+			variables: map[string]string{
+				"installation": "golem",
+				"app":          "",
+				"stage":        "testing",
+			},
+		},
+		{
+			name:     "built-in variables are never unknown",
+			declared: declared,
+			variables: map[string]string{
+				"installation":        "golem",
+				"app":                 "app-operator",
+				IterationNameVariable: "app-operator",
+				RevisionVariable:      "38be874bfa3d627bf70366bd3ae43ff9dcfb4fcf",
+			},
+		},
+		{
+			name:     "missing required variable",
+			declared: declared,
+			variables: map[string]string{
+				"installation": "golem",
+			},
+			expectedError: `invalid variables: required variable "app" is not set`,
+		},
+		{
+			name:     "typo is reported as missing and unknown",
+			declared: declared,
+			variables: map[string]string{
+				"installation": "golem",
+				"ap":           "app-operator",
+				"stag":         "testing",
+			},
+			expectedError: `invalid variables: required variable "app" is not set, variable "ap" is not declared in the schema, variable "stag" is not declared in the schema`,
+		},
+		{
+			name:     "nothing declared, but variables set",
+			declared: nil,
+			variables: map[string]string{
+				"installation": "golem",
+			},
+			expectedError: `invalid variables: variable "installation" is not declared in the schema`,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("case %d: %s", i, tc.name), func(t *testing.T) {
+			err := ValidateVariables(tc.declared, tc.variables)
+
+			if tc.expectedError == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+
+				return
+			}
+
+			if err == nil {
+				t.Fatalf("expected error: %s, got none", tc.expectedError)
+			}
+
+			if err.Error() != tc.expectedError {
+				t.Fatalf("error does not match, expected: %s, got: %s", tc.expectedError, err.Error())
+			}
+		})
+	}
+}
@@ -0,0 +1,170 @@
+package logic
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// BuiltinVariablePrefix is reserved for variables injected by the operator into every iteration.
+	BuiltinVariablePrefix = "konfiguration."
+
+	IterationNameVariable        = BuiltinVariablePrefix + "iteration"
+	TargetNameVariable           = BuiltinVariablePrefix + "targetName"
+	DestinationNamespaceVariable = BuiltinVariablePrefix + "destinationNamespace"
+	OwnerNameVariable            = BuiltinVariablePrefix + "name"
+	OwnerNamespaceVariable       = BuiltinVariablePrefix + "namespace"
+	RevisionVariable             = BuiltinVariablePrefix + "revision"
+)
+
+// GenerateBuiltinVariables returns the built-in variables of an iteration, except for TargetNameVariable.
+// The target name may be templated from the resolved variables, thus it is up to the caller to set it.
+func GenerateBuiltinVariables(meta v1.ObjectMeta, iterationName, destinationNamespace, revision string) map[string]string {
+	variables := map[string]string{}
+
+	variables[IterationNameVariable] = iterationName
+	variables[DestinationNamespaceVariable] = destinationNamespace
+
+	variables[OwnerNameVariable] = meta.Name
+	variables[OwnerNamespaceVariable] = meta.Namespace
+
+	variables[RevisionVariable] = revision
+
+	return variables
+}
+
+// ResolveVariables merges the built-in variables with the user defined ones and interpolates `${name}` references
+// in all values. References may point to user defined and built-in variables alike, `$${` escapes a literal `${`.
+// User defined variables must not use the reserved BuiltinVariablePrefix.
+func ResolveVariables(variables, builtins map[string]string) (map[string]string, error) {
+	merged := make(map[string]string, len(variables)+len(builtins))
+
+	for name, value := range variables {
+		if strings.HasPrefix(name, BuiltinVariablePrefix) {
+			return nil, fmt.Errorf("variable \"%s\" uses the reserved prefix \"%s\"", name, BuiltinVariablePrefix)
+		}
+
+		merged[name] = value
+	}
+
+	for name, value := range builtins {
+		merged[name] = value
+	}
+
+	resolver := &variableResolver{
+		raw:      merged,
+		resolved: make(map[string]string, len(merged)),
+	}
+
+	// Sort to always report the same error for the same input.
+	names := make([]string, 0, len(merged))
+	for name := range merged {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	for _, name := range names {
+		if _, err := resolver.resolve(name, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	return resolver.resolved, nil
+}
+
+// FormatRawVariables converts the variables to the `name=value` format konfigure expects, sorted by name.
+func FormatRawVariables(variables map[string]string) []string {
+	rawVariables := make([]string, 0, len(variables))
+	for name, value := range variables {
+		rawVariables = append(rawVariables, fmt.Sprintf("%s=%s", name, value))
+	}
+
+	slices.Sort(rawVariables)
+
+	return rawVariables
+}
+
+// Interpolate replaces `${name}` references in the value with the given, already resolved variables.
+// The subject describes the owner of the value in error messages, e.g. `rollout trigger "app"`.
+func Interpolate(subject, value string, variables map[string]string) (string, error) {
+	return interpolate(subject, value, func(reference string) (string, error) {
+		resolved, ok := variables[reference]
+		if !ok {
+			return "", fmt.Errorf("%s references undefined variable \"%s\"", subject, reference)
+		}
+
+		return resolved, nil
+	})
+}
+
+type variableResolver struct {
+	raw      map[string]string
+	resolved map[string]string
+}
+
+func (r *variableResolver) resolve(name string, stack []string) (string, error) {
+	if value, ok := r.resolved[name]; ok {
+		return value, nil
+	}
+
+	if slices.Contains(stack, name) {
+		return "", fmt.Errorf("circular variable reference: %s", strings.Join(append(stack, name), " -> "))
+	}
+
+	raw, ok := r.raw[name]
+	if !ok {
+		return "", fmt.Errorf("variable \"%s\" references undefined variable \"%s\"", stack[len(stack)-1], name)
+	}
+
+	stack = append(stack, name)
+
+	value, err := interpolate(fmt.Sprintf("variable \"%s\"", name), raw, func(reference string) (string, error) {
+		return r.resolve(reference, stack)
+	})
+	if err != nil {
+		return "", err
+	}
+
+	r.resolved[name] = value
+
+	return r.resolved[name], nil
+}
+
+// interpolate replaces all `${name}` references in raw with the value returned by lookup. The subject is used to
+// describe the owner of the value in error messages.
+func interpolate(subject, raw string, lookup func(reference string) (string, error)) (string, error) {
+	var builder strings.Builder
+	for i := 0; i < len(raw); {
+		switch {
+		case strings.HasPrefix(raw[i:], "$${"):
+			builder.WriteString("${")
+			i += 3
+		case strings.HasPrefix(raw[i:], "${"):
+			end := strings.IndexByte(raw[i+2:], '}')
+			if end < 0 {
+				return "", fmt.Errorf("%s has an unterminated reference in value: %s", subject, raw)
+			}
+
+			reference := strings.TrimSpace(raw[i+2 : i+2+end])
+			if reference == "" {
+				return "", fmt.Errorf("%s has an empty reference in value: %s", subject, raw)
+			}
+
+			value, err := lookup(reference)
+			if err != nil {
+				return "", err
+			}
+
+			builder.WriteString(value)
+			i += end + 3
+		default:
+			builder.WriteByte(raw[i])
+			i++
+		}
+	}
+
+	return builder.String(), nil
+}
@@ -0,0 +1,218 @@
+package logic
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestResolveVariables(t *testing.T) {
+	builtins := GenerateBuiltinVariables(
+		v1.ObjectMeta{Name: "example-1", Namespace: "giantswarm"},
+		"app-operator",
+		"default",
+		"38be874bfa3d627bf70366bd3ae43ff9dcfb4fcf",
+	)
+	builtins[TargetNameVariable] = "app-operator-example-1"
+
+	testCases := []struct {
+		name          string
+		variables     map[string]string
+		builtins      map[string]string
+		expected      map[string]string
+		expectedError string
+	}{
+		{
+			name:      "nil inputs should result in empty map",
+			variables: nil,
+			builtins:  nil,
+			expected:  map[string]string{},
+		},
+		{
+			name: "values without references are kept as is",
+			variables: map[string]string{
+				"installation": "golem",
+				"app":          "app-operator",
+			},
+			expected: map[string]string{
+				"installation": "golem",
+				"app":          "app-operator",
+			},
+		},
+		{
+			name: "references between variables are resolved transitively",
+			variables: map[string]string{
+				"installation": "golem",
+				"cluster":      "${installation}-mc",
+				"path":         "clusters/${cluster}/${ installation }",
+			},
+			expected: map[string]string{
+				"installation": "golem",
+				"cluster":      "golem-mc",
+				"path":         "clusters/golem-mc/golem",
+			},
+		},
+		{
+			name: "built-in variables are injected and can be referenced",
+			variables: map[string]string{
+				"app": "${" + IterationNameVariable + "}",
+			},
+			builtins: builtins,
+			expected: map[string]string{
+				"app":                        "app-operator",
+				IterationNameVariable:        "app-operator",
+				TargetNameVariable:           "app-operator-example-1",
+				DestinationNamespaceVariable: "default",
+				OwnerNameVariable:            "example-1",
+				OwnerNamespaceVariable:       "giantswarm",
+				RevisionVariable:             "38be874bfa3d627bf70366bd3ae43ff9dcfb4fcf",
+			},
+		},
+		{
+			name: "escaped references are kept literally",
+			variables: map[string]string{
+				"installation": "golem",
+				"literal":      "$${installation} is ${installation}",
+			},
+			expected: map[string]string{
+				"installation": "golem",
+				"literal":      "${installation} is golem",
+			},
+		},
+		{
+			name: "dollar signs without braces are kept as is",
+			variables: map[string]string{
+				"price": "$5 $ {x}",
+			},
+			expected: map[string]string{
+				"price": "$5 $ {x}",
+			},
+		},
+		{
+			name: "reserved prefix is rejected",
+			variables: map[string]string{
+				RevisionVariable: "main",
+			},
+			expectedError: `variable "konfiguration.revision" uses the reserved prefix "konfiguration."`,
+		},
+		{
+			name: "undefined reference",
+			variables: map[string]string{
+				"cluster": "${installation}-mc",
+			},
+			expectedError: `variable "cluster" references undefined variable "installation"`,
+		},
+		{
+			// Values written before interpolation existed must escape literal references.
+			name: "pre-existing literal reference must be escaped",
+			variables: map[string]string{
+				"script": "echo ${HOME}",
+			},
+			expectedError: `variable "script" references undefined variable "HOME"`,
+		},
+		{
+			name: "pre-existing literal reference escaped",
+			variables: map[string]string{
+				"script": "echo $${HOME}",
+			},
+			expected: map[string]string{
+				"script": "echo ${HOME}",
+			},
+		},
+		{
+			name: "circular reference",
+			variables: map[string]string{
+				"a": "${b}",
+				"b": "${c}",
+				"c": "${a}",
+			},
+			expectedError: "circular variable reference: a -> b -> c -> a",
+		},
+		{
+			name: "self reference",
+			variables: map[string]string{
+				"a": "x-${a}",
+			},
+			expectedError: "circular variable reference: a -> a",
+		},
+		{
+			name: "unterminated reference",
+			variables: map[string]string{
+				"a": "${b",
+			},
+			expectedError: `variable "a" has an unterminated reference in value: ${b`,
+		},
+		{
+			name: "empty reference",
+			variables: map[string]string{
+				"a": "${ }",
+			},
+			expectedError: `variable "a" has an empty reference in value: ${ }`,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("case %d: %s", i, tc.name), func(t *testing.T) {
+			result, err := ResolveVariables(tc.variables, tc.builtins)
+
+			if tc.expectedError != "" {
+				if err == nil {
+					t.Fatalf("expected error: %s, got none", tc.expectedError)
+				}
+
+				if err.Error() != tc.expectedError {
+					t.Fatalf("error does not match, expected: %s, got: %s", tc.expectedError, err.Error())
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !reflect.DeepEqual(result, tc.expected) {
+				t.Fatalf("expected result: %v, got: %v", tc.expected, result)
+			}
+		})
+	}
+}
+
+func TestFormatRawVariables(t *testing.T) {
+	result := FormatRawVariables(map[string]string{
+		"installation": "golem",
+		"app":          "app-operator",
+		"expression":   "a=b",
+	})
+
+	expected := []string{"app=app-operator", "expression=a=b", "installation=golem"}
+
+	if !reflect.DeepEqual(result, expected) {
+		t.Fatalf("expected result: %v, got: %v", expected, result)
+	}
+}
+
+func TestInterpolate(t *testing.T) {
+	variables := map[string]string{
+		IterationNameVariable: "app-operator",
+		"cluster":             "golem",
+	}
+
+	result, err := Interpolate(`rollout trigger "x"`, "${cluster}-${konfiguration.iteration}-$${literal}", variables)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result != "golem-app-operator-${literal}" {
+		t.Fatalf("result does not match, got: %s", result)
+	}
+
+	_, err = Interpolate(`rollout trigger "x"`, "${installation}", variables)
+
+	expectedError := `rollout trigger "x" references undefined variable "installation"`
+	if err == nil || err.Error() != expectedError {
+		t.Fatalf("expected error: %s, got: %v", expectedError, err)
+	}
+}
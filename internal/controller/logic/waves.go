@@ -0,0 +1,142 @@
+package logic
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	konfigurev1alpha1 "github.com/giantswarm/konfigure-operator/api/v1alpha1"
+)
+
+// FinalWaveName is the name of the wave rolling out the iterations not selected by any wave.
+const FinalWaveName = "remaining"
+
+// Wave is a group of iterations rolled out together.
+type Wave struct {
+	Name       string
+	Iterations []string
+
+	SoakTime     time.Duration
+	HealthChecks []konfigurev1alpha1.HealthCheck
+}
+
+// RolloutWaves groups the iterations into the waves of the rollout, in order. Iterations belong to the first wave
+// selecting them, iterations not selected by any wave to a final wave. Waves without iterations are left out.
+func RolloutWaves(rollout *konfigurev1alpha1.Rollout, iterations map[string]konfigurev1alpha1.Iteration) ([]Wave, error) {
+	if rollout == nil {
+		return nil, nil
+	}
+
+	remaining := slices.Sorted(maps.Keys(iterations))
+
+	var waves []Wave
+	for _, spec := range rollout.Waves {
+		selector := labels.Nothing()
+		if spec.Selector != nil {
+			var err error
+			selector, err = v1.LabelSelectorAsSelector(spec.Selector)
+			if err != nil {
+				return nil, fmt.Errorf("invalid selector of rollout wave %s: %w", spec.Name, err)
+			}
+		}
+
+		wave := Wave{
+			Name:         spec.Name,
+			SoakTime:     spec.GetSoakTime(),
+			HealthChecks: spec.HealthChecks,
+		}
+
+		remaining = slices.DeleteFunc(remaining, func(name string) bool {
+			if !slices.Contains(spec.Iterations, name) && !selector.Matches(labels.Set(iterations[name].Labels)) {
+				return false
+			}
+
+			wave.Iterations = append(wave.Iterations, name)
+			return true
+		})
+
+		if len(wave.Iterations) > 0 {
+			waves = append(waves, wave)
+		}
+	}
+
+	if len(remaining) > 0 {
+		waves = append(waves, Wave{Name: FinalWaveName, Iterations: remaining})
+	}
+
+	return waves, nil
+}
+
+// StartRollout returns the rollout of the given revision. The rollout of the same revision carries on, a new revision
+// starts over from the first wave. Revisions are not staged when nothing was applied before, or when they are applied
+// to all iterations already.
+func StartRollout(current *konfigurev1alpha1.RolloutStatus, revision, lastAppliedRevision string, waves []Wave) *konfigurev1alpha1.RolloutStatus {
+	if len(waves) == 0 {
+		return nil
+	}
+
+	total := int32(len(waves))
+
+	if current != nil && current.Revision == revision {
+		rollout := current.DeepCopy()
+		rollout.TotalWaves = total
+
+		// Waves may have been removed since the rollout started.
+		if rollout.CompletedWaves >= total {
+			rollout.CompletedWaves = total
+			rollout.Phase = konfigurev1alpha1.RolloutPhaseCompleted
+			rollout.Wave = waves[total-1].Name
+			rollout.WaveAppliedAt = nil
+		} else if rollout.Phase != konfigurev1alpha1.RolloutPhaseCompleted {
+			rollout.Wave = waves[rollout.CompletedWaves].Name
+		}
+
+		return rollout
+	}
+
+	if lastAppliedRevision == "" || lastAppliedRevision == revision {
+		message := fmt.Sprintf("Revision %s is applied to all waves", revision)
+		if lastAppliedRevision == "" {
+			message = fmt.Sprintf("Revision %s is not staged, as no revision was applied before", revision)
+		}
+
+		return &konfigurev1alpha1.RolloutStatus{
+			Revision:       revision,
+			Phase:          konfigurev1alpha1.RolloutPhaseCompleted,
+			Wave:           waves[total-1].Name,
+			CompletedWaves: total,
+			TotalWaves:     total,
+			Message:        message,
+		}
+	}
+
+	return &konfigurev1alpha1.RolloutStatus{
+		Revision:         revision,
+		PreviousRevision: lastAppliedRevision,
+		Phase:            konfigurev1alpha1.RolloutPhaseProgressing,
+		Wave:             waves[0].Name,
+		TotalWaves:       total,
+		Message:          fmt.Sprintf("Rolling out revision %s to wave %s", revision, waves[0].Name),
+	}
+}
+
+// PendingIterations returns the iterations of the waves the rollout did not start yet.
+func PendingIterations(rollout *konfigurev1alpha1.RolloutStatus, waves []Wave) map[string]bool {
+	pending := map[string]bool{}
+
+	if rollout == nil || rollout.Phase == konfigurev1alpha1.RolloutPhaseCompleted {
+		return pending
+	}
+
+	for _, wave := range waves[min(int(rollout.CompletedWaves)+1, len(waves)):] {
+		for _, name := range wave.Iterations {
+			pending[name] = true
+		}
+	}
+
+	return pending
+}
@@ -0,0 +1,175 @@
+package logic
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	konfigurev1alpha1 "github.com/giantswarm/konfigure-operator/api/v1alpha1"
+)
+
+func TestRolloutWaves(t *testing.T) {
+	iterations := map[string]konfigurev1alpha1.Iteration{
+		"a": {Labels: map[string]string{"stage": "canary"}},
+		"b": {Labels: map[string]string{"stage": "canary"}},
+		"c": {},
+		"d": {Labels: map[string]string{"stage": "production"}},
+	}
+
+	testCases := []struct {
+		name          string
+		rollout       *konfigurev1alpha1.Rollout
+		expected      map[string][]string
+		expectedOrder []string
+		expectedError bool
+	}{
+		{
+			name:     "no rollout",
+			expected: map[string][]string{},
+		},
+		{
+			name: "label selectors and explicit iterations",
+			rollout: &konfigurev1alpha1.Rollout{Waves: []konfigurev1alpha1.RolloutWave{
+				{Name: "canary", Selector: &v1.LabelSelector{MatchLabels: map[string]string{"stage": "canary"}}, SoakTime: &v1.Duration{Duration: time.Minute}},
+				{Name: "explicit", Iterations: []string{"c", "a"}},
+			}},
+			expected:      map[string][]string{"canary": {"a", "b"}, "explicit": {"c"}, FinalWaveName: {"d"}},
+			expectedOrder: []string{"canary", "explicit", FinalWaveName},
+		},
+		{
+			name: "empty waves are left out",
+			rollout: &konfigurev1alpha1.Rollout{Waves: []konfigurev1alpha1.RolloutWave{
+				{Name: "none", Iterations: []string{"x"}},
+				{Name: "all", Selector: &v1.LabelSelector{}},
+			}},
+			expected:      map[string][]string{"all": {"a", "b", "c", "d"}},
+			expectedOrder: []string{"all"},
+		},
+		{
+			name: "invalid selector",
+			rollout: &konfigurev1alpha1.Rollout{Waves: []konfigurev1alpha1.RolloutWave{
+				{Name: "invalid", Selector: &v1.LabelSelector{MatchExpressions: []v1.LabelSelectorRequirement{{Key: "stage", Operator: "Unknown"}}}},
+			}},
+			expectedError: true,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("case %d: %s", i, tc.name), func(t *testing.T) {
+			waves, err := RolloutWaves(tc.rollout, iterations)
+
+			if tc.expectedError {
+				if err == nil {
+					t.Fatalf("expected error, got waves: %+v", waves)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			result := map[string][]string{}
+			var order []string
+			for _, wave := range waves {
+				result[wave.Name] = wave.Iterations
+				order = append(order, wave.Name)
+			}
+
+			if !reflect.DeepEqual(result, tc.expected) || !reflect.DeepEqual(order, tc.expectedOrder) {
+				t.Fatalf("expected waves %v in order %v, got %v in order %v", tc.expected, tc.expectedOrder, result, order)
+			}
+		})
+	}
+}
+
+func TestStartRollout(t *testing.T) {
+	waves := []Wave{{Name: "canary", Iterations: []string{"a"}}, {Name: "production", Iterations: []string{"b", "c"}}}
+
+	testCases := []struct {
+		name               string
+		current            *konfigurev1alpha1.RolloutStatus
+		lastApplied        string
+		expectedPhase      konfigurev1alpha1.RolloutPhase
+		expectedWave       string
+		expectedPending    []string
+		expectedPreviousAt string
+	}{
+		{
+			name:          "first revision is not staged",
+			lastApplied:   "",
+			expectedPhase: konfigurev1alpha1.RolloutPhaseCompleted,
+			expectedWave:  "production",
+		},
+		{
+			name:          "applied revision is not staged",
+			lastApplied:   "new",
+			expectedPhase: konfigurev1alpha1.RolloutPhaseCompleted,
+			expectedWave:  "production",
+		},
+		{
+			name:               "new revision starts with the first wave",
+			current:            &konfigurev1alpha1.RolloutStatus{Revision: "old", Phase: konfigurev1alpha1.RolloutPhaseCompleted, CompletedWaves: 2},
+			lastApplied:        "old",
+			expectedPhase:      konfigurev1alpha1.RolloutPhaseProgressing,
+			expectedWave:       "canary",
+			expectedPending:    []string{"b", "c"},
+			expectedPreviousAt: "old",
+		},
+		{
+			name:               "rollout of the same revision carries on",
+			current:            &konfigurev1alpha1.RolloutStatus{Revision: "new", PreviousRevision: "old", Phase: konfigurev1alpha1.RolloutPhaseProgressing, CompletedWaves: 1},
+			lastApplied:        "old",
+			expectedPhase:      konfigurev1alpha1.RolloutPhaseProgressing,
+			expectedWave:       "production",
+			expectedPreviousAt: "old",
+		},
+		{
+			name:               "halted rollout stays halted",
+			current:            &konfigurev1alpha1.RolloutStatus{Revision: "new", PreviousRevision: "old", Phase: konfigurev1alpha1.RolloutPhaseHalted, CompletedWaves: 0},
+			lastApplied:        "old",
+			expectedPhase:      konfigurev1alpha1.RolloutPhaseHalted,
+			expectedWave:       "canary",
+			expectedPending:    []string{"b", "c"},
+			expectedPreviousAt: "old",
+		},
+		{
+			name:               "removed waves complete the rollout",
+			current:            &konfigurev1alpha1.RolloutStatus{Revision: "new", PreviousRevision: "old", Phase: konfigurev1alpha1.RolloutPhaseProgressing, CompletedWaves: 3},
+			lastApplied:        "old",
+			expectedPhase:      konfigurev1alpha1.RolloutPhaseCompleted,
+			expectedWave:       "production",
+			expectedPreviousAt: "old",
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("case %d: %s", i, tc.name), func(t *testing.T) {
+			rollout := StartRollout(tc.current, "new", tc.lastApplied, waves)
+
+			if rollout.Revision != "new" || rollout.Phase != tc.expectedPhase || rollout.Wave != tc.expectedWave || rollout.PreviousRevision != tc.expectedPreviousAt {
+				t.Fatalf("expected %s rollout of new from %q at wave %s, got %+v", tc.expectedPhase, tc.expectedPreviousAt, tc.expectedWave, rollout)
+			}
+
+			var pending []string
+			for _, wave := range waves {
+				for _, name := range wave.Iterations {
+					if PendingIterations(rollout, waves)[name] {
+						pending = append(pending, name)
+					}
+				}
+			}
+
+			if !reflect.DeepEqual(pending, tc.expectedPending) {
+				t.Fatalf("expected pending iterations %v, got %v", tc.expectedPending, pending)
+			}
+		})
+	}
+
+	if rollout := StartRollout(nil, "new", "old", nil); rollout != nil {
+		t.Fatalf("expected no rollout without waves, got %+v", rollout)
+	}
+}
@@ -0,0 +1,132 @@
+package logic
+
+import (
+	"fmt"
+	"time"
+	// The operator image does not ship the time zone database.
+	_ "time/tzdata"
+
+	konfigurev1alpha1 "github.com/giantswarm/konfigure-operator/api/v1alpha1"
+)
+
+// maxWindowBoundaries bounds the number of windows opening and closing looked at to find the next time applying new
+// revisions is allowed, so windows that deny applying forever do not loop forever.
+const maxWindowBoundaries = 1000
+
+// Window is a maintenance window with its schedule parsed.
+type Window struct {
+	Type     konfigurev1alpha1.WindowType
+	Schedule *Schedule
+	Duration time.Duration
+	Location *time.Location
+}
+
+// ParseWindows parses the schedules and time zones of the maintenance windows.
+func ParseWindows(specs []konfigurev1alpha1.MaintenanceWindow) ([]Window, error) {
+	var windows []Window
+
+	for i, spec := range specs {
+		schedule, err := ParseSchedule(spec.Schedule)
+		if err != nil {
+			return nil, fmt.Errorf("invalid maintenance window %d: %w", i, err)
+		}
+
+		if spec.Duration.Duration <= 0 {
+			return nil, fmt.Errorf("invalid maintenance window %d: duration must be positive, got %s", i, spec.Duration.Duration)
+		}
+
+		location, err := time.LoadLocation(spec.TimeZone)
+		if err != nil {
+			return nil, fmt.Errorf("invalid maintenance window %d: %w", i, err)
+		}
+
+		windowType := spec.Type
+		if windowType == "" {
+			windowType = konfigurev1alpha1.WindowTypeAllow
+		}
+
+		windows = append(windows, Window{
+			Type:     windowType,
+			Schedule: schedule,
+			Duration: spec.Duration.Duration,
+			Location: location,
+		})
+	}
+
+	return windows, nil
+}
+
+// openedAt returns the last time the window opened at, if it is open at the given time.
+func (w Window) openedAt(t time.Time) (time.Time, bool) {
+	local := t.In(w.Location)
+
+	opened := w.Schedule.Next(local.Add(-w.Duration))
+	if opened.IsZero() || opened.After(local) {
+		return time.Time{}, false
+	}
+
+	for next := w.Schedule.Next(opened); !next.IsZero() && !next.After(local); next = w.Schedule.Next(next) {
+		opened = next
+	}
+
+	return opened, true
+}
+
+// nextBoundary returns the next time after the given one the window opens or closes at, the zero time if never.
+func (w Window) nextBoundary(t time.Time) time.Time {
+	if opened, open := w.openedAt(t); open {
+		return opened.Add(w.Duration)
+	}
+
+	return w.Schedule.Next(t.In(w.Location))
+}
+
+// ApplyAllowed tells whether new revisions may be applied at the given time: while any Allow window is open, or at
+// any time without Allow windows, unless a Deny window is open.
+func ApplyAllowed(windows []Window, t time.Time) bool {
+	allowed, restricted := false, false
+
+	for _, window := range windows {
+		_, open := window.openedAt(t)
+
+		if window.Type == konfigurev1alpha1.WindowTypeDeny {
+			if open {
+				return false
+			}
+			continue
+		}
+
+		restricted = true
+		allowed = allowed || open
+	}
+
+	return allowed || !restricted
+}
+
+// NextApplyTime returns the first time from the given one on at which new revisions may be applied. It returns the
+// zero time when the windows do not allow applying in the foreseeable future.
+func NextApplyTime(windows []Window, now time.Time) time.Time {
+	t := now
+
+	for range maxWindowBoundaries {
+		if ApplyAllowed(windows, t) {
+			return t
+		}
+
+		var next time.Time
+		for _, window := range windows {
+			boundary := window.nextBoundary(t)
+			if !boundary.IsZero() && (next.IsZero() || boundary.Before(next)) {
+				next = boundary
+			}
+		}
+
+		if next.IsZero() {
+			return time.Time{}
+		}
+
+		t = next
+	}
+
+	return time.Time{}
+}
@@ -0,0 +1,145 @@
+package logic
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	konfigurev1alpha1 "github.com/giantswarm/konfigure-operator/api/v1alpha1"
+)
+
+func TestParseWindows(t *testing.T) {
+	testCases := []struct {
+		name          string
+		window        konfigurev1alpha1.MaintenanceWindow
+		expectedError bool
+	}{
+		{
+			name:   "valid window",
+			window: konfigurev1alpha1.MaintenanceWindow{Schedule: "0 9 * * 1-5", Duration: v1.Duration{Duration: 8 * time.Hour}, TimeZone: "Europe/Berlin"},
+		},
+		{
+			name:          "invalid schedule",
+			window:        konfigurev1alpha1.MaintenanceWindow{Schedule: "0 9 * *", Duration: v1.Duration{Duration: time.Hour}},
+			expectedError: true,
+		},
+		{
+			name:          "zero duration",
+			window:        konfigurev1alpha1.MaintenanceWindow{Schedule: "0 9 * * *"},
+			expectedError: true,
+		},
+		{
+			name:          "unknown time zone",
+			window:        konfigurev1alpha1.MaintenanceWindow{Schedule: "0 9 * * *", Duration: v1.Duration{Duration: time.Hour}, TimeZone: "Mars/Olympus"},
+			expectedError: true,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("case %d: %s", i, tc.name), func(t *testing.T) {
+			windows, err := ParseWindows([]konfigurev1alpha1.MaintenanceWindow{tc.window})
+
+			if tc.expectedError {
+				if err == nil {
+					t.Fatalf("expected error, got none")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if windows[0].Type != konfigurev1alpha1.WindowTypeAllow {
+				t.Fatalf("expected type to default to %s, got %s", konfigurev1alpha1.WindowTypeAllow, windows[0].Type)
+			}
+		})
+	}
+}
+
+func TestNextApplyTime(t *testing.T) {
+	businessHours := konfigurev1alpha1.MaintenanceWindow{
+		Type:     konfigurev1alpha1.WindowTypeAllow,
+		Schedule: "0 9 * * 1-5",
+		Duration: v1.Duration{Duration: 8 * time.Hour},
+		TimeZone: "Europe/Berlin",
+	}
+	lunch := konfigurev1alpha1.MaintenanceWindow{
+		Type:     konfigurev1alpha1.WindowTypeDeny,
+		Schedule: "0 12 * * *",
+		Duration: v1.Duration{Duration: time.Hour},
+		TimeZone: "Europe/Berlin",
+	}
+	freeze := konfigurev1alpha1.MaintenanceWindow{
+		Type:     konfigurev1alpha1.WindowTypeDeny,
+		Schedule: "0 0 20 12 *",
+		Duration: v1.Duration{Duration: 14 * 24 * time.Hour},
+	}
+
+	testCases := []struct {
+		name     string
+		windows  []konfigurev1alpha1.MaintenanceWindow
+		now      time.Time
+		expected time.Time
+	}{
+		{
+			name:     "no windows",
+			now:      time.Date(2025, 3, 12, 3, 0, 0, 0, time.UTC),
+			expected: time.Date(2025, 3, 12, 3, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "inside allow window",
+			windows:  []konfigurev1alpha1.MaintenanceWindow{businessHours},
+			now:      time.Date(2025, 3, 12, 10, 0, 0, 0, time.UTC),
+			expected: time.Date(2025, 3, 12, 10, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "before allow window in its time zone",
+			windows:  []konfigurev1alpha1.MaintenanceWindow{businessHours},
+			now:      time.Date(2025, 3, 12, 7, 30, 0, 0, time.UTC),
+			expected: time.Date(2025, 3, 12, 8, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "after allow window on a friday",
+			windows:  []konfigurev1alpha1.MaintenanceWindow{businessHours},
+			now:      time.Date(2025, 3, 14, 16, 0, 0, 0, time.UTC),
+			expected: time.Date(2025, 3, 17, 8, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "deny window inside allow window",
+			windows:  []konfigurev1alpha1.MaintenanceWindow{businessHours, lunch},
+			now:      time.Date(2025, 3, 12, 11, 15, 0, 0, time.UTC),
+			expected: time.Date(2025, 3, 12, 12, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "deny window spanning days",
+			windows:  []konfigurev1alpha1.MaintenanceWindow{businessHours, freeze},
+			now:      time.Date(2025, 12, 22, 10, 0, 0, 0, time.UTC),
+			expected: time.Date(2026, 1, 5, 8, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "denied forever",
+			windows: []konfigurev1alpha1.MaintenanceWindow{
+				{Type: konfigurev1alpha1.WindowTypeDeny, Schedule: "* * * * *", Duration: v1.Duration{Duration: time.Hour}},
+			},
+			now: time.Date(2025, 3, 12, 10, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("case %d: %s", i, tc.name), func(t *testing.T) {
+			windows, err := ParseWindows(tc.windows)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			result := NextApplyTime(windows, tc.now)
+
+			if !result.Equal(tc.expected) {
+				t.Fatalf("expected %s, got %s", tc.expected, result)
+			}
+		})
+	}
+}
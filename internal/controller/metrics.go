@@ -38,6 +38,22 @@ var (
 		[]string{"resource_kind", "resource_name", "resource_namespace", "iteration_name", "destination_namespace"},
 	)
 
+	applyCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "konfigure_operator_apply_total",
+			Help: "Total number of rendered manifests applied, labelled by kind and outcome: Created, Updated or Unchanged.",
+		},
+		[]string{"resource_kind", "resource_name", "resource_namespace", "iteration_name", "kind", "outcome"},
+	)
+
+	failureCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "konfigure_operator_failure_total",
+			Help: "Total number of failed iterations and setups, labelled by the classified reason. The iteration name is empty for setup failures.",
+		},
+		[]string{"resource_kind", "resource_name", "resource_namespace", "iteration_name", "reason"},
+	)
+
 	reconcileDurationHistogram = prometheus.NewHistogramVec(
 		prometheus.HistogramOpts{
 			Name: "konfigure_operator_reconcile_duration_seconds",
@@ -48,6 +64,26 @@ var (
 		[]string{"resource_kind", "resource_name", "resource_namespace"},
 	)
 
+	renderDurationHistogram = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name: "konfigure_operator_iteration_render_duration_seconds",
+			Help: "The duration in seconds of rendering a single iteration of a Konfigure Operator resource.",
+			// Use a histogram with 10 count buckets between 1ms - 5min
+			Buckets: prometheus.ExponentialBucketsRange(10e-3, 300, 10),
+		},
+		[]string{"resource_kind", "resource_name", "resource_namespace"},
+	)
+
+	applyDurationHistogram = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name: "konfigure_operator_iteration_apply_duration_seconds",
+			Help: "The duration in seconds of applying the rendered output of a single iteration of a Konfigure Operator resource.",
+			// Use a histogram with 10 count buckets between 1ms - 5min
+			Buckets: prometheus.ExponentialBucketsRange(10e-3, 300, 10),
+		},
+		[]string{"resource_kind", "resource_name", "resource_namespace"},
+	)
+
 	schemaFetchCounter = prometheus.NewCounterVec(
 		prometheus.CounterOpts{
 			Name: "konfigure_operator_schema_fetch_total",
@@ -74,23 +110,46 @@ func RecordCondition(kind string, meta v1.ObjectMeta, condition v1.Condition) {
 	}
 }
 
-func RecordRendering(obj *konfigurev1alpha1.Konfiguration, iterationName string, success bool) {
+func RecordRendering(obj *konfigurev1alpha1.Konfiguration, iterationName, destinationNamespace string, success bool) {
 	var value float64
 	if success {
 		value = 1
 	}
 
-	renderingGauge.WithLabelValues(obj.Kind, obj.Name, obj.Namespace, iterationName, obj.Spec.Destination.Namespace).Set(value)
+	// The destination namespace of the iteration may have changed, so the series of the previous one must not linger.
+	renderingGauge.DeletePartialMatch(prometheus.Labels{
+		"resource_kind":      obj.Kind,
+		"resource_name":      obj.Name,
+		"resource_namespace": obj.Namespace,
+		"iteration_name":     iterationName,
+	})
+	renderingGauge.WithLabelValues(obj.Kind, obj.Name, obj.Namespace, iterationName, destinationNamespace).Set(value)
+}
+
+func RecordApply(obj *konfigurev1alpha1.Konfiguration, iterationName, kind string, outcome konfigurev1alpha1.ApplyOutcome) {
+	applyCounter.WithLabelValues(obj.Kind, obj.Name, obj.Namespace, iterationName, kind, string(outcome)).Inc()
+}
+
+func RecordFailure(obj *konfigurev1alpha1.Konfiguration, iterationName, reason string) {
+	failureCounter.WithLabelValues(obj.Kind, obj.Name, obj.Namespace, iterationName, reason).Inc()
 }
 
 func RecordReconcileDuration(gvk schema.GroupVersionKind, meta v1.ObjectMeta, start time.Time) {
 	reconcileDurationHistogram.WithLabelValues(gvk.Kind, meta.Name, meta.Namespace).Observe(time.Since(start).Seconds())
 }
 
+func RecordRenderDuration(obj *konfigurev1alpha1.Konfiguration, duration time.Duration) {
+	renderDurationHistogram.WithLabelValues(obj.Kind, obj.Name, obj.Namespace).Observe(duration.Seconds())
+}
+
+func RecordApplyDuration(obj *konfigurev1alpha1.Konfiguration, duration time.Duration) {
+	applyDurationHistogram.WithLabelValues(obj.Kind, obj.Name, obj.Namespace).Observe(duration.Seconds())
+}
+
 func RecordSchemaFetch(schemaUrl string, statusCode int) {
 	schemaFetchCounter.WithLabelValues(schemaUrl, strconv.Itoa(statusCode)).Inc()
 }
 
 func init() {
-	metrics.Registry.MustRegister(conditionGauge, generationGauge, renderingGauge, reconcileDurationHistogram, schemaFetchCounter)
+	metrics.Registry.MustRegister(conditionGauge, generationGauge, renderingGauge, applyCounter, failureCounter, reconcileDurationHistogram, renderDurationHistogram, applyDurationHistogram, schemaFetchCounter)
 }
@@ -0,0 +1,33 @@
+package controller
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	konfigurev1alpha1 "github.com/giantswarm/konfigure-operator/api/v1alpha1"
+)
+
+func TestRecordRenderingMovedDestination(t *testing.T) {
+	cr := &konfigurev1alpha1.Konfiguration{
+		TypeMeta:   metav1.TypeMeta{Kind: "Konfiguration"},
+		ObjectMeta: metav1.ObjectMeta{Name: "metrics-example", Namespace: "default"},
+	}
+
+	RecordRendering(cr, "app", "old", true)
+	RecordRendering(cr, "other", "old", true)
+	RecordRendering(cr, "app", "new", false)
+
+	if value := testutil.ToFloat64(renderingGauge.WithLabelValues("Konfiguration", "metrics-example", "default", "app", "new")); value != 0 {
+		t.Fatalf("expected rendering of the new destination to be recorded as failed, got %v", value)
+	}
+
+	if deleted := renderingGauge.DeleteLabelValues("Konfiguration", "metrics-example", "default", "app", "old"); deleted {
+		t.Fatalf("expected rendering of the previous destination to be removed")
+	}
+
+	if deleted := renderingGauge.DeleteLabelValues("Konfiguration", "metrics-example", "default", "other", "old"); !deleted {
+		t.Fatalf("expected rendering of other iterations to be kept")
+	}
+}
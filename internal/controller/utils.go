@@ -5,50 +5,47 @@ import (
 	"os"
 	"path"
 
-	"github.com/giantswarm/konfigure/v2/pkg/fluxupdater"
 	"github.com/giantswarm/konfigure/v2/pkg/sopsenv"
+	v1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
-	konfigurev1alpha1 "github.com/giantswarm/konfigure-operator/api/v1alpha1"
+	"github.com/giantswarm/konfigure-operator/internal/controller/logic"
 	"github.com/giantswarm/konfigure-operator/internal/konfigure"
 )
 
-func InitializeSopsEnv(ctx context.Context, dir string) (*sopsenv.SOPSEnv, error) {
-	err := os.MkdirAll(path.Clean(dir), 0700)
-	if err != nil {
-		return nil, err
-	}
-
-	sopsEnv, err := konfigure.InitializeSopsEnvFromKubernetes(ctx, dir)
-
-	if err != nil {
-		return nil, err
+// SopsKeysDigest returns a digest of the Secrets holding the SOPS keys, which changes whenever any of the keys does.
+func SopsKeysDigest(ctx context.Context, reader client.Reader) (string, error) {
+	secrets := &v1.SecretList{}
+	if err := reader.List(ctx, secrets, client.MatchingLabels{sopsenv.KonfigureLabelKey: sopsenv.KonfigureLabelValue}); err != nil {
+		return "", err
 	}
 
-	err = sopsEnv.Setup(ctx)
-	if err != nil {
-		return sopsEnv, err
+	keys := make(map[string][]byte)
+	for _, secret := range secrets.Items {
+		for name, key := range secret.Data {
+			keys[path.Join(secret.Namespace, secret.Name, name)] = key
+		}
 	}
 
-	return sopsEnv, nil
+	return logic.ContentHash(keys), nil
 }
 
-func InitializeFluxUpdater(dir string, fluxSource konfigurev1alpha1.FluxSource) (*fluxupdater.FluxUpdater, error) {
+func InitializeSopsEnv(ctx context.Context, dir string) (*sopsenv.SOPSEnv, error) {
 	err := os.MkdirAll(path.Clean(dir), 0700)
 	if err != nil {
 		return nil, err
 	}
 
-	fluxUpdater, err := konfigure.InitializeFluxUpdater(dir, fluxSource.GitRepository.Namespace, fluxSource.GitRepository.Name)
+	sopsEnv, err := konfigure.InitializeSopsEnvFromKubernetes(ctx, dir)
 
 	if err != nil {
 		return nil, err
 	}
 
-	err = fluxUpdater.UpdateConfig()
-
+	err = sopsEnv.Setup(ctx)
 	if err != nil {
-		return fluxUpdater, err
+		return sopsEnv, err
 	}
 
-	return fluxUpdater, nil
+	return sopsEnv, nil
 }
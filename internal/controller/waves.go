@@ -0,0 +1,145 @@
+package controller
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	apiMachineryErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	konfigurev1alpha1 "github.com/giantswarm/konfigure-operator/api/v1alpha1"
+	"github.com/giantswarm/konfigure-operator/internal/controller/logic"
+)
+
+// nextWaveDelay is the time to wait before starting the next wave of a rollout, once the previous one passed.
+const nextWaveDelay = time.Second
+
+// progressRollout moves the rollout on to the next wave once the iterations of the current one were applied without
+// failures, soaked and passed its health checks. Failures of iterations in later waves do not hold it. A wave failing
+// its health checks halts the rollout. It returns when to reconcile again to carry on, zero when the rollout does not
+// wait for anything.
+func (r *KonfigurationReconciler) progressRollout(ctx context.Context, cr *konfigurev1alpha1.Konfiguration, rollout *konfigurev1alpha1.RolloutStatus, waves []logic.Wave, plans map[string]iterationPlan, failures map[string]*logic.ClassifiedError) time.Duration {
+	logger := log.FromContext(ctx)
+
+	if rollout.Phase != konfigurev1alpha1.RolloutPhaseProgressing {
+		return 0
+	}
+
+	wave := waves[rollout.CompletedWaves]
+
+	failed := slices.ContainsFunc(wave.Iterations, func(iterationName string) bool {
+		return failures[iterationName] != nil
+	})
+
+	// The soak time starts over once the failures are fixed.
+	if failed {
+		rollout.WaveAppliedAt = nil
+		rollout.Message = fmt.Sprintf("Waiting for failed iterations to be fixed to continue rolling out revision %s at wave %s", rollout.Revision, wave.Name)
+		return 0
+	}
+
+	now := time.Now()
+	if rollout.WaveAppliedAt == nil {
+		rollout.WaveAppliedAt = &metav1.Time{Time: now}
+	}
+
+	soakedAt := rollout.WaveAppliedAt.Add(wave.SoakTime)
+	if soakedAt.After(now) {
+		rollout.Message = fmt.Sprintf("Soaking revision %s at wave %s until %s", rollout.Revision, wave.Name, soakedAt.Format(time.RFC3339))
+		return soakedAt.Sub(now)
+	}
+
+	if unhealthy := r.checkWaveHealth(ctx, wave, plans); len(unhealthy) > 0 {
+		rollout.Phase = konfigurev1alpha1.RolloutPhaseHalted
+		rollout.Message = fmt.Sprintf("Halted rolling out revision %s at wave %s: %s", rollout.Revision, wave.Name, strings.Join(unhealthy, ", "))
+
+		logger.Info(rollout.Message)
+		r.Recorder.Event(cr, v1.EventTypeWarning, logic.RolloutHaltedReason, rollout.Message)
+		return 0
+	}
+
+	rollout.CompletedWaves++
+	rollout.WaveAppliedAt = nil
+
+	r.Recorder.Eventf(cr, v1.EventTypeNormal, logic.RolloutProgressingReason, "Rolled out revision %s to wave %s", rollout.Revision, wave.Name)
+
+	if int(rollout.CompletedWaves) == len(waves) {
+		rollout.Phase = konfigurev1alpha1.RolloutPhaseCompleted
+		rollout.Message = fmt.Sprintf("Rolled out revision %s to all %d waves", rollout.Revision, len(waves))
+		return 0
+	}
+
+	rollout.Wave = waves[rollout.CompletedWaves].Name
+	rollout.Message = fmt.Sprintf("Rolling out revision %s to wave %s", rollout.Revision, rollout.Wave)
+
+	return nextWaveDelay
+}
+
+// checkWaveHealth checks the health checks of the wave for each of its iterations and describes the workloads that
+// are not healthy.
+func (r *KonfigurationReconciler) checkWaveHealth(ctx context.Context, wave logic.Wave, plans map[string]iterationPlan) []string {
+	var unhealthy []string
+	checked := map[string]bool{}
+
+	for _, iterationName := range wave.Iterations {
+		plan := plans[iterationName]
+
+		for _, check := range wave.HealthChecks {
+			subject := fmt.Sprintf("health check %s \"%s\"", check.Kind, check.Name)
+
+			name, err := logic.Interpolate(subject, check.Name, plan.variables)
+			if err != nil {
+				unhealthy = append(unhealthy, err.Error())
+				continue
+			}
+
+			namespace, err := logic.Interpolate(subject, cmp.Or(check.Namespace, plan.targetNamespace), plan.variables)
+			if err != nil {
+				unhealthy = append(unhealthy, err.Error())
+				continue
+			}
+
+			// Iterations may share workloads to check.
+			workload := fmt.Sprintf("%s %s/%s", check.Kind, namespace, name)
+			if checked[workload] {
+				continue
+			}
+			checked[workload] = true
+
+			if healthy, message := r.workloadHealth(ctx, check.Kind, name, namespace); !healthy {
+				unhealthy = append(unhealthy, fmt.Sprintf("%s: %s", workload, message))
+			}
+		}
+	}
+
+	return unhealthy
+}
+
+func (r *KonfigurationReconciler) workloadHealth(ctx context.Context, kind konfigurev1alpha1.RolloutTriggerKind, name, namespace string) (bool, string) {
+	gvk, err := logic.RolloutTriggerGVK(kind)
+	if err != nil {
+		return false, err.Error()
+	}
+
+	workload := &unstructured.Unstructured{}
+	workload.SetGroupVersionKind(gvk)
+
+	err = r.Get(ctx, client.ObjectKey{Name: name, Namespace: namespace}, workload)
+	if apiMachineryErrors.IsNotFound(err) {
+		return false, "not found"
+	}
+
+	if err != nil {
+		return false, fmt.Sprintf("failed to get: %s", err)
+	}
+
+	return logic.WorkloadHealth(kind, workload)
+}
@@ -0,0 +1,50 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	konfigurev1alpha1 "github.com/giantswarm/konfigure-operator/api/v1alpha1"
+	"github.com/giantswarm/konfigure-operator/internal/controller/logic"
+)
+
+func TestProgressRollout(t *testing.T) {
+	r := &KonfigurationReconciler{Recorder: record.NewFakeRecorder(10)}
+	cr := &konfigurev1alpha1.Konfiguration{}
+
+	waves := []logic.Wave{
+		{Name: "canary", Iterations: []string{"a"}, SoakTime: time.Hour},
+		{Name: "production", Iterations: []string{"b"}},
+	}
+	rollout := logic.StartRollout(nil, "new", "old", waves)
+
+	failed := func(iterationName string) map[string]*logic.ClassifiedError {
+		return map[string]*logic.ClassifiedError{
+			iterationName: logic.NewClassifiedError(logic.RenderFailedReason, errors.New("failed")),
+		}
+	}
+
+	if requeueAfter := r.progressRollout(context.Background(), cr, rollout, waves, nil, failed("a")); requeueAfter != 0 || rollout.WaveAppliedAt != nil {
+		t.Fatalf("expected failed wave to wait for fixes, got requeue after %s with %+v", requeueAfter, rollout)
+	}
+
+	// Failures of iterations in later waves do not hold the current one.
+	requeueAfter := r.progressRollout(context.Background(), cr, rollout, waves, nil, failed("b"))
+	if requeueAfter <= 0 || requeueAfter > time.Hour || rollout.Wave != "canary" || rollout.WaveAppliedAt == nil {
+		t.Fatalf("expected wave canary to soak for an hour, got requeue after %s with %+v", requeueAfter, rollout)
+	}
+
+	rollout.WaveAppliedAt = &metav1.Time{Time: time.Now().Add(-2 * time.Hour)}
+	if requeueAfter = r.progressRollout(context.Background(), cr, rollout, waves, nil, nil); requeueAfter != nextWaveDelay || rollout.Wave != "production" || rollout.CompletedWaves != 1 {
+		t.Fatalf("expected rollout to move on to wave production, got requeue after %s with %+v", requeueAfter, rollout)
+	}
+
+	if requeueAfter = r.progressRollout(context.Background(), cr, rollout, waves, nil, nil); requeueAfter != 0 || rollout.Phase != konfigurev1alpha1.RolloutPhaseCompleted || rollout.CompletedWaves != 2 {
+		t.Fatalf("expected rollout to complete, got requeue after %s with %+v", requeueAfter, rollout)
+	}
+}
@@ -0,0 +1,183 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"os"
+	"path"
+	"sync"
+
+	"github.com/giantswarm/konfigure/v2/pkg/sopsenv"
+	"golang.org/x/sync/semaphore"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	konfigurev1alpha1 "github.com/giantswarm/konfigure-operator/api/v1alpha1"
+)
+
+const (
+	DefaultWorkspaceDir = "/tmp/konfigure-workspaces"
+	DefaultSopsKeysDir  = "/sopsenv/kfg"
+
+	// sopsLockWeight is the weight of the SOPS keys lock. Reconciliations decrypting take one, updating the keys takes
+	// all of it.
+	sopsLockWeight = math.MaxInt64
+)
+
+// WorkspaceManager hands out an isolated directory to each reconciliation, so different Konfigurations can be
+// reconciled in parallel. The SOPS keys are shared between reconciliations, so they are guarded by a lock: they are
+// updated exclusively, only when they changed, and read concurrently. Sources are shared through the ArtifactCache.
+type WorkspaceManager struct {
+	dir         string
+	sopsKeysDir string
+
+	// sops guards the SOPS keys directory. SOPS is pointed to it by environment variables of the process, so there
+	// can only be one for all reconciliations. Unlike a sync.RWMutex, waiting for it is bounded by a context.
+	sops *semaphore.Weighted
+
+	// sopsEnv is the SOPS environment set up last, for the keys of sopsKeysDigest.
+	sopsEnv        *sopsenv.SOPSEnv
+	sopsKeysDigest string
+	sopsEnvMutex   sync.Mutex
+
+	// held are the workspaces not released yet. Workers of a timed out reconciliation may still read its workspace
+	// while the next reconciliation of the same Konfiguration runs.
+	held      map[string]bool
+	heldMutex sync.Mutex
+}
+
+func NewWorkspaceManager(dir, sopsKeysDir string) *WorkspaceManager {
+	return &WorkspaceManager{
+		dir:         dir,
+		sopsKeysDir: sopsKeysDir,
+		sops:        semaphore.NewWeighted(sopsLockWeight),
+		held:        map[string]bool{},
+	}
+}
+
+// Workspace is the directory of a single reconciliation of a Konfiguration.
+type Workspace struct {
+	Dir       string
+	SchemaDir string
+
+	manager *WorkspaceManager
+}
+
+// Acquire creates the workspace of a reconciliation of the given Konfiguration. Leftovers of interrupted
+// reconciliations are removed, unless they are still held.
+func (m *WorkspaceManager) Acquire(cr *konfigurev1alpha1.Konfiguration) (*Workspace, error) {
+	m.heldMutex.Lock()
+	defer m.heldMutex.Unlock()
+
+	base := path.Join(m.dir, cr.GetNamespace(), cr.GetName())
+
+	if err := os.MkdirAll(base, 0700); err != nil {
+		return nil, err
+	}
+
+	entries, err := os.ReadDir(base)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, entry := range entries {
+		leftover := path.Join(base, entry.Name())
+		if m.held[leftover] {
+			continue
+		}
+
+		if err := os.RemoveAll(leftover); err != nil {
+			return nil, err
+		}
+	}
+
+	dir, err := os.MkdirTemp(base, "run-")
+	if err != nil {
+		return nil, err
+	}
+
+	workspace := &Workspace{
+		Dir:       dir,
+		SchemaDir: path.Join(dir, "schemas"),
+		manager:   m,
+	}
+
+	if err := os.MkdirAll(workspace.SchemaDir, 0700); err != nil {
+		return nil, err
+	}
+
+	m.held[dir] = true
+
+	return workspace, nil
+}
+
+// Release removes the workspace with all its content.
+func (w *Workspace) Release() error {
+	w.manager.heldMutex.Lock()
+	defer w.manager.heldMutex.Unlock()
+
+	delete(w.manager.held, w.Dir)
+
+	return os.RemoveAll(w.Dir)
+}
+
+// SetupSops imports the SOPS keys into the shared keys directory, while no other reconciliation decrypts the source.
+// Keys that did not change since they were imported last are not imported again, so reconciliations only wait for
+// others to stop decrypting when the keys changed, and at most as long as the context allows.
+func (w *Workspace) SetupSops(ctx context.Context, reader client.Reader) (*sopsenv.SOPSEnv, error) {
+	digest, err := SopsKeysDigest(ctx, reader)
+	if err != nil {
+		return nil, err
+	}
+
+	if sopsEnv := w.manager.currentSopsEnv(digest); sopsEnv != nil {
+		return sopsEnv, nil
+	}
+
+	if err := w.manager.sops.Acquire(ctx, sopsLockWeight); err != nil {
+		return nil, fmt.Errorf("waiting for other reconciliations to finish decrypting to update the SOPS keys: %w", err)
+	}
+	defer w.manager.sops.Release(sopsLockWeight)
+
+	// Another reconciliation may have imported the same keys meanwhile.
+	if sopsEnv := w.manager.currentSopsEnv(digest); sopsEnv != nil {
+		return sopsEnv, nil
+	}
+
+	sopsEnv, err := InitializeSopsEnv(ctx, w.manager.sopsKeysDir)
+	if err != nil {
+		return sopsEnv, err
+	}
+
+	w.manager.sopsEnvMutex.Lock()
+	defer w.manager.sopsEnvMutex.Unlock()
+
+	w.manager.sopsEnv = sopsEnv
+	w.manager.sopsKeysDigest = digest
+
+	return sopsEnv, nil
+}
+
+// currentSopsEnv returns the SOPS environment set up last if it holds the keys of the given digest, nil otherwise.
+func (m *WorkspaceManager) currentSopsEnv(digest string) *sopsenv.SOPSEnv {
+	m.sopsEnvMutex.Lock()
+	defer m.sopsEnvMutex.Unlock()
+
+	if m.sopsKeysDigest != digest {
+		return nil
+	}
+
+	return m.sopsEnv
+}
+
+// ReadSops blocks updates of the SOPS keys until the returned function is called. Waiting for an update in progress
+// is bounded by the context, as it may in turn wait for decryptions hanging in reconciliations that timed out.
+func (w *Workspace) ReadSops(ctx context.Context) (func(), error) {
+	if err := w.manager.sops.Acquire(ctx, 1); err != nil {
+		return nil, fmt.Errorf("waiting for the SOPS keys to be updated: %w", err)
+	}
+
+	return func() {
+		w.manager.sops.Release(1)
+	}, nil
+}
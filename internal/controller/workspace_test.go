@@ -0,0 +1,140 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/giantswarm/konfigure/v2/pkg/sopsenv"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	konfigurev1alpha1 "github.com/giantswarm/konfigure-operator/api/v1alpha1"
+)
+
+func TestWorkspaceAcquireAndRelease(t *testing.T) {
+	root := t.TempDir()
+	manager := NewWorkspaceManager(root, path.Join(root, "sopsenv"))
+
+	cr := &konfigurev1alpha1.Konfiguration{ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: "default"}}
+
+	leftover := path.Join(root, "default", "example", "schemas", "leftover")
+	if err := os.MkdirAll(path.Dir(leftover), 0700); err != nil {
+		t.Fatalf("error creating leftover directory: %v", err)
+	}
+	if err := os.WriteFile(leftover, []byte("stale"), 0600); err != nil {
+		t.Fatalf("error writing leftover file: %v", err)
+	}
+
+	workspace, err := manager.Acquire(cr)
+	if err != nil {
+		t.Fatalf("unexpected error on acquiring workspace: %v", err)
+	}
+
+	if _, err = os.Stat(leftover); !os.IsNotExist(err) {
+		t.Fatalf("expected leftover of previous reconciliation to be removed, got: %v", err)
+	}
+
+	if info, err := os.Stat(workspace.SchemaDir); err != nil || !info.IsDir() {
+		t.Fatalf("expected schema directory to exist, got: %v", err)
+	}
+
+	other, err := manager.Acquire(&konfigurev1alpha1.Konfiguration{ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: "other"}})
+	if err != nil {
+		t.Fatalf("unexpected error on acquiring workspace: %v", err)
+	}
+
+	if other.Dir == workspace.Dir {
+		t.Fatalf("expected distinct workspaces, both got: %s", workspace.Dir)
+	}
+
+	// Workers of a timed out reconciliation may still read the workspace during the next reconciliation.
+	next, err := manager.Acquire(cr)
+	if err != nil {
+		t.Fatalf("unexpected error on acquiring workspace: %v", err)
+	}
+
+	if next.Dir == workspace.Dir {
+		t.Fatalf("expected distinct workspaces for each reconciliation, both got: %s", workspace.Dir)
+	}
+
+	if _, err = os.Stat(workspace.SchemaDir); err != nil {
+		t.Fatalf("expected held workspace to be kept, got: %v", err)
+	}
+
+	if err = workspace.Release(); err != nil {
+		t.Fatalf("unexpected error on releasing workspace: %v", err)
+	}
+
+	if _, err = os.Stat(next.SchemaDir); err != nil {
+		t.Fatalf("expected next workspace to be kept, got: %v", err)
+	}
+
+	if _, err = os.Stat(workspace.Dir); !os.IsNotExist(err) {
+		t.Fatalf("expected workspace to be removed, got: %v", err)
+	}
+
+	if _, err = os.Stat(other.SchemaDir); err != nil {
+		t.Fatalf("expected other workspace to be kept, got: %v", err)
+	}
+}
+
+// sopsKeysReader lists the given Secrets as the SOPS keys.
+type sopsKeysReader struct {
+	client.Reader
+
+	secrets []v1.Secret
+}
+
+func (r *sopsKeysReader) List(_ context.Context, list client.ObjectList, _ ...client.ListOption) error {
+	list.(*v1.SecretList).Items = r.secrets
+	return nil
+}
+
+func TestWorkspaceSetupSops(t *testing.T) {
+	root := t.TempDir()
+	manager := NewWorkspaceManager(root, path.Join(root, "sopsenv"))
+
+	workspace, err := manager.Acquire(&konfigurev1alpha1.Konfiguration{ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: "default"}})
+	if err != nil {
+		t.Fatalf("unexpected error on acquiring workspace: %v", err)
+	}
+
+	reader := &sopsKeysReader{secrets: []v1.Secret{
+		{ObjectMeta: metav1.ObjectMeta{Name: "keys", Namespace: "default"}, Data: map[string][]byte{"example.agekey": []byte("key")}},
+	}}
+
+	digest, err := SopsKeysDigest(context.Background(), reader)
+	if err != nil {
+		t.Fatalf("unexpected error on digesting keys: %v", err)
+	}
+
+	// As if the keys were imported by an earlier reconciliation.
+	imported := &sopsenv.SOPSEnv{}
+	manager.sopsEnv, manager.sopsKeysDigest = imported, digest
+
+	// Another reconciliation is decrypting, e.g. one abandoned on timeout.
+	releaseSops, err := workspace.ReadSops(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error on reading SOPS keys: %v", err)
+	}
+	defer releaseSops()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	sopsEnv, err := workspace.SetupSops(ctx, reader)
+	if err != nil || sopsEnv != imported {
+		t.Fatalf("expected unchanged keys to be set up without waiting, got: %v", err)
+	}
+
+	reader.secrets[0].Data["example.agekey"] = []byte("rotated")
+
+	if _, err = workspace.SetupSops(ctx, reader); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected changed keys to wait for decryptions until the context is done, got: %v", err)
+	}
+}
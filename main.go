@@ -25,6 +25,7 @@ import (
 	"github.com/giantswarm/konfigure-operator/internal/controller/logic"
 
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/labels"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -70,6 +71,12 @@ func main() {
 	var tlsOpts []func(*tls.Config)
 	var schemaFetchTimeout time.Duration
 	var schemaFetchIdleConnTimeout time.Duration
+	var iterationWorkers int
+	var maxConcurrentReconciles int
+	var reconcileTimeout time.Duration
+	var intervalJitterPercentage int
+	var artifactCacheMaxSize string
+	var changeFreeze bool
 	flag.BoolVar(&verbose, "verbose", false, "Enable verbose logging.")
 	flag.StringVar(&metricsAddr, "metrics-bind-address", "0", "The address the metrics endpoint binds to. "+
 		"Use :8443 for HTTPS or :8080 for HTTP, or leave as 0 to disable the metrics service.")
@@ -85,6 +92,18 @@ func main() {
 		"Timeout for the overall HTTP request when fetching a remote konfiguration schema.")
 	flag.DurationVar(&schemaFetchIdleConnTimeout, "schema-fetch-idle-conn-timeout", 30*time.Second,
 		"Idle connection timeout for the HTTP client used to fetch remote konfiguration schemas.")
+	flag.IntVar(&iterationWorkers, "iteration-workers", controller.DefaultIterationWorkers,
+		"Number of iterations of a single Konfiguration rendered and applied in parallel.")
+	flag.IntVar(&maxConcurrentReconciles, "max-concurrent-reconciles", 1,
+		"Number of different Konfigurations reconciled in parallel.")
+	flag.DurationVar(&reconcileTimeout, "reconcile-timeout", 10*time.Minute,
+		"Maximum duration of a single reconciliation of Konfigurations not setting .spec.reconciliation.timeout. 0 disables the timeout.")
+	flag.IntVar(&intervalJitterPercentage, "interval-jitter-percentage", 5,
+		"Percentage by which reconciliation and retry intervals are randomly shifted in either direction. 0 disables the jitter.")
+	flag.StringVar(&artifactCacheMaxSize, "artifact-cache-max-size", "24Mi",
+		"Size of the source artifact cache above which old revisions are evicted. Keep it below the size of the cache volume.")
+	flag.BoolVar(&changeFreeze, "change-freeze", false,
+		"If set, new revisions of all Konfigurations are rendered and reported as pending, but not applied.")
 	opts := zap.Options{
 		Development: true,
 	}
@@ -99,6 +118,19 @@ func main() {
 		setupLog.Error(nil, "invalid value for --schema-fetch-idle-conn-timeout: must be >= 0", "value", schemaFetchIdleConnTimeout)
 		os.Exit(1)
 	}
+	if reconcileTimeout < 0 {
+		setupLog.Error(nil, "invalid value for --reconcile-timeout: must be >= 0", "value", reconcileTimeout)
+		os.Exit(1)
+	}
+	if intervalJitterPercentage < 0 || intervalJitterPercentage >= 100 {
+		setupLog.Error(nil, "invalid value for --interval-jitter-percentage: must be >= 0 and < 100", "value", intervalJitterPercentage)
+		os.Exit(1)
+	}
+	artifactCacheMaxSizeQuantity, err := resource.ParseQuantity(artifactCacheMaxSize)
+	if err != nil || artifactCacheMaxSizeQuantity.Sign() <= 0 {
+		setupLog.Error(err, "invalid value for --artifact-cache-max-size: must be a positive quantity", "value", artifactCacheMaxSize)
+		os.Exit(1)
+	}
 
 	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
 
@@ -182,12 +214,19 @@ func main() {
 	}
 
 	if err = (&controller.KonfigurationReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor("konfigure-operator"),
 		Options: controller.KonfigurationReconcilerOptions{
 			Verbose:                    verbose,
 			SchemaFetchTimeout:         schemaFetchTimeout,
 			SchemaFetchIdleConnTimeout: schemaFetchIdleConnTimeout,
+			IterationWorkers:           iterationWorkers,
+			MaxConcurrentReconciles:    maxConcurrentReconciles,
+			ReconcileTimeout:           reconcileTimeout,
+			IntervalJitterPercentage:   intervalJitterPercentage,
+			ArtifactCacheMaxSize:       artifactCacheMaxSizeQuantity.Value(),
+			ChangeFreeze:               changeFreeze,
 		},
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Konfiguration")